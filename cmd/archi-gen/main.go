@@ -6,10 +6,9 @@ import (
 
 	"github.com/spf13/cobra"
 	"github.com/tuza/scaffolding-code-generation/internal/command"
+	"github.com/tuza/scaffolding-code-generation/internal/version"
 )
 
-var version = "1.0.0"
-
 func main() {
 	rootCmd := &cobra.Command{
 		Use:   "archi-gen",
@@ -24,16 +23,24 @@ func main() {
 
 技术栈：
   - Go 1.24+
-  - Gin (HTTP 框架)
+  - Hertz / Gin / chi (HTTP 框架)
   - GORM (ORM)
-  - PostgreSQL (数据库)
+  - PostgreSQL / MySQL / SQLite (数据库)
   - Redis (缓存，可选)
   - Docker (容器化部署)`,
-		Version: version,
+		Version: version.Version,
 	}
 
 	// 添加子命令
 	rootCmd.AddCommand(command.NewInitCommand())
+	rootCmd.AddCommand(command.NewAddCommand())
+	rootCmd.AddCommand(command.NewUpgradeCommand())
+	rootCmd.AddCommand(command.NewDiffCommand())
+	rootCmd.AddCommand(command.NewTemplatesCommand())
+	rootCmd.AddCommand(command.NewBOMCommand())
+
+	// 添加全局参数
+	command.AddGlobalFlags(rootCmd)
 
 	// 执行命令
 	if err := rootCmd.Execute(); err != nil {
@@ -0,0 +1,205 @@
+package command
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/spf13/cobra"
+	"github.com/tuza/scaffolding-code-generation/internal/generator"
+	"github.com/tuza/scaffolding-code-generation/internal/spec"
+	"github.com/tuza/scaffolding-code-generation/internal/template"
+)
+
+// aggregateNamePattern 聚合名称格式
+var aggregateNamePattern = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9_-]*$`)
+
+// reservedAggregateNames 与项目固定模块冲突的名称
+var reservedAggregateNames = map[string]bool{
+	"api":   true,
+	"bom":   true,
+	"cmd":   true,
+	"share": true,
+}
+
+// NewAddCommand 创建 add 命令
+func NewAddCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "add",
+		Short: "向已有项目中添加组件",
+		Long:  `向 archi-gen 生成的已有项目中添加新的组件，例如新的聚合（限界上下文）。`,
+	}
+
+	cmd.AddCommand(newAddAggregateCommand())
+	cmd.AddCommand(newAddEntityCommand())
+
+	return cmd
+}
+
+// addAggregateOptions add aggregate 命令参数
+type addAggregateOptions struct {
+	projectDir    string
+	errorCodeBase int
+}
+
+func newAddAggregateCommand() *cobra.Command {
+	opts := &addAggregateOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "aggregate <name>",
+		Short: "添加一个新的聚合（限界上下文）",
+		Long: `在已有项目中生成一个新的聚合，结构与 user 聚合一致：
+  - <name>/domain          领域层模块
+  - <name>/infrastructure  基础设施层模块
+  - api/<name>-api         API 模块
+
+同时会更新 go.work、Makefile 的 tidy 目标、Dockerfile 的 COPY 指令，
+以及 cmd/api 的 go.mod 和 main.go 中的迁移与路由注册。`,
+		Example: `  archi-gen add aggregate order
+  archi-gen add aggregate order-item --dir ./my-project`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runAddAggregate(opts, spec.DefaultEntity(args[0]))
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.projectDir, "dir", "d", ".", "项目根目录")
+	cmd.Flags().IntVar(&opts.errorCodeBase, "error-code", 0, "错误码分段起始值（例如 12000），默认按已有聚合数量自动分配")
+
+	return cmd
+}
+
+// addEntityOptions add entity 命令参数
+type addEntityOptions struct {
+	addAggregateOptions
+	specFile string
+}
+
+func newAddEntityCommand() *cobra.Command {
+	opts := &addEntityOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "entity <name> [field:type[:modifier]...]",
+		Short: "按字段定义添加一个新的聚合（限界上下文）",
+		Long: `根据字段定义生成聚合根实体及其完整的 CRUD 代码：
+  - 领域实体、枚举、错误码、仓储接口、领域服务
+  - 带 gorm 标签的 PO 及双向转换器
+  - 带 vd 校验标签的请求 DTO、响应 VO 及 CRUD 处理器
+
+字段格式: name:type[(args)][:required][:unique][:index]
+  类型: string(size) text int int64 float decimal bool uuid time enum(a,b,c)
+  主键: id:uuid（默认）或 id:int64（自增）
+
+也可以通过 --file 从 YAML 文件读取实体定义:
+
+  name: Order
+  fields:
+    - id:uuid
+    - total:decimal:required
+    - name: status
+      type: enum
+      values: [pending, paid, cancelled]`,
+		Example: `  archi-gen add entity Order id:uuid total:decimal:required status:enum(pending,paid,cancelled)
+  archi-gen add entity Product sku:string(32):required:unique price:decimal stock:int
+  archi-gen add entity --file order.yaml --dir ./my-project`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			entity, err := loadEntitySpec(opts.specFile, args)
+			if err != nil {
+				return err
+			}
+			return runAddAggregate(&opts.addAggregateOptions, entity)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.projectDir, "dir", "d", ".", "项目根目录")
+	cmd.Flags().IntVar(&opts.errorCodeBase, "error-code", 0, "错误码分段起始值（例如 12000），默认按已有聚合数量自动分配")
+	cmd.Flags().StringVarP(&opts.specFile, "file", "f", "", "实体定义 YAML 文件")
+
+	return cmd
+}
+
+// loadEntitySpec 从 YAML 文件或命令行参数加载实体定义
+func loadEntitySpec(specFile string, args []string) (*spec.Entity, error) {
+	if specFile != "" {
+		if len(args) > 0 {
+			return nil, fmt.Errorf("使用 --file 时不能再通过参数指定实体名称和字段")
+		}
+		entity, err := spec.LoadFile(specFile)
+		if err != nil {
+			return nil, fmt.Errorf("加载实体定义失败: %w", err)
+		}
+		return entity, nil
+	}
+
+	if len(args) < 2 {
+		return nil, fmt.Errorf("需要指定实体名称和至少一个字段，例如: archi-gen add entity Order total:decimal")
+	}
+	fields, err := spec.ParseFields(args[1:])
+	if err != nil {
+		return nil, err
+	}
+	return spec.NewEntity(args[0], fields)
+}
+
+func runAddAggregate(opts *addAggregateOptions, entity *spec.Entity) error {
+	name := entity.Name
+	if err := validateAggregateName(name); err != nil {
+		return err
+	}
+
+	proj, err := loadProject(opts.projectDir)
+	if err != nil {
+		return fmt.Errorf("读取项目配置失败: %w", err)
+	}
+	cfg, projectDir := proj.config, proj.dir
+
+	errorCodeBase := opts.errorCodeBase
+	if errorCodeBase == 0 {
+		errorCodeBase = proj.manifest.NextErrorCodeBase()
+	}
+	if errorCodeBase == 0 {
+		errorCodeBase, err = generator.NextErrorCodeBase(projectDir)
+		if err != nil {
+			return fmt.Errorf("计算错误码分段失败: %w", err)
+		}
+	}
+
+	templates, err := proj.resolveTemplates()
+	if err != nil {
+		return err
+	}
+	if err := proj.resolveVersions(); err != nil {
+		return err
+	}
+
+	fmt.Println()
+	fmt.Printf("✨ 正在向项目 %s 添加聚合 %s...\n", cfg.ProjectName, template.ToSnakeCase(name))
+	fmt.Println()
+
+	gen := generator.NewAggregateGenerator(cfg, projectDir, proj.manifest, entity, errorCodeBase)
+	gen.SetTemplates(templates, proj.manifest.Templates)
+	if err := gen.Generate(); err != nil {
+		return fmt.Errorf("添加聚合失败: %w", err)
+	}
+
+	fmt.Println()
+	fmt.Println("🎉 聚合添加成功!")
+	fmt.Println()
+	fmt.Println("🚀 下一步:")
+	fmt.Printf("   cd %s\n", projectDir)
+	fmt.Println("   make tidy")
+	fmt.Println("   go run ./cmd/api")
+	fmt.Println()
+
+	return nil
+}
+
+// validateAggregateName 校验聚合名称
+func validateAggregateName(name string) error {
+	if !aggregateNamePattern.MatchString(name) {
+		return fmt.Errorf("聚合名称 '%s' 无效: 必须以字母开头，只能包含字母、数字、下划线和中划线", name)
+	}
+	if reservedAggregateNames[template.ToSnakeCase(name)] {
+		return fmt.Errorf("聚合名称 '%s' 与项目固定模块冲突", name)
+	}
+	return nil
+}
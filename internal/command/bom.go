@@ -0,0 +1,121 @@
+package command
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	"github.com/tuza/scaffolding-code-generation/internal/config"
+	"github.com/tuza/scaffolding-code-generation/internal/generator"
+	"github.com/tuza/scaffolding-code-generation/internal/modgraph"
+)
+
+// versionsFile --versions 全局参数
+var versionsFile string
+
+// resolveVersions 确定本次使用的依赖版本，优先级：--versions > 全局配置 > 项目清单中记录的版本
+// recorded 为清单中记录的版本（新项目为 nil），返回覆盖内置版本目录的版本（模块路径 -> 版本）
+func resolveVersions(recorded map[string]string) (map[string]string, error) {
+	path := versionsFile
+	if path == "" {
+		global, err := config.LoadGlobalConfig()
+		if err != nil {
+			return nil, err
+		}
+		path = global.Versions
+	}
+	if path == "" {
+		return recorded, nil
+	}
+
+	versions, err := modgraph.LoadVersions(path)
+	if err != nil {
+		return nil, fmt.Errorf("读取依赖版本文件失败: %w", err)
+	}
+	return versions, nil
+}
+
+// NewBOMCommand 创建 bom 命令
+func NewBOMCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "bom",
+		Short: "管理生成项目的依赖版本（BOM）",
+	}
+
+	cmd.AddCommand(newBOMCheckCommand())
+
+	return cmd
+}
+
+// newBOMCheckCommand 创建 bom check 命令
+func newBOMCheckCommand() *cobra.Command {
+	var projectDir string
+
+	cmd := &cobra.Command{
+		Use:   "check",
+		Short: "检查各模块的依赖版本是否与 bom/go.mod 一致",
+		Long: `读取 go.work 中的全部模块，将每个模块 go.mod 中 require 的版本与 bom/go.mod 对比，
+列出版本不一致的依赖（包括 go mod tidy 加入的间接依赖），存在不一致时以非零状态退出。
+
+bom/go.mod 中没有的依赖不检查。统一升级依赖时先修改 bom/go.mod，
+再根据本命令的输出修改其他模块。`,
+		Example: `  archi-gen bom check
+  archi-gen bom check --dir ./my-project`,
+		Args: cobra.NoArgs,
+		// 存在不一致时返回错误只是为了设置退出码，不需要打印用法
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runBOMCheck(projectDir)
+		},
+	}
+
+	cmd.Flags().StringVarP(&projectDir, "dir", "d", ".", "项目根目录")
+
+	return cmd
+}
+
+func runBOMCheck(projectDir string) error {
+	bom, err := os.ReadFile(filepath.Join(projectDir, "bom", "go.mod"))
+	if err != nil {
+		return fmt.Errorf("读取 bom/go.mod 失败: %w", err)
+	}
+	dirs, err := generator.WorkspaceModules(projectDir)
+	if err != nil {
+		return fmt.Errorf("读取 go.work 失败: %w", err)
+	}
+
+	modules := make(map[string][]byte, len(dirs))
+	for _, dir := range dirs {
+		if dir == "bom" {
+			continue
+		}
+		content, err := os.ReadFile(filepath.Join(projectDir, dir, "go.mod"))
+		if err != nil {
+			return err
+		}
+		modules[dir] = content
+	}
+
+	drifts, err := modgraph.CheckBOM(bom, modules)
+	if err != nil {
+		return err
+	}
+	if len(drifts) == 0 {
+		fmt.Printf("✔ %d 个模块的依赖版本与 bom/go.mod 一致\n", len(modules))
+		return nil
+	}
+
+	for _, d := range drifts {
+		note := "低于 BOM"
+		if d.Newer() {
+			note = "高于 BOM"
+		}
+		if d.Indirect {
+			note += "，间接依赖"
+		}
+		fmt.Printf("✘ %s/go.mod: %s %s（bom/go.mod 中为 %s，%s）\n", d.Module, d.Path, d.Version, d.BOMVersion, note)
+	}
+	fmt.Println()
+	return fmt.Errorf("发现 %d 处依赖版本与 bom/go.mod 不一致", len(drifts))
+}
@@ -0,0 +1,116 @@
+package command
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/tuza/scaffolding-code-generation/internal/diff"
+	"github.com/tuza/scaffolding-code-generation/internal/generator"
+	"github.com/tuza/scaffolding-code-generation/internal/manifest"
+	"github.com/tuza/scaffolding-code-generation/internal/output"
+)
+
+// diffOptions diff 命令参数
+type diffOptions struct {
+	projectDir string
+	context    int
+	stat       bool
+	exitCode   bool
+}
+
+// NewDiffCommand 创建 diff 命令
+func NewDiffCommand() *cobra.Command {
+	opts := &diffOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "diff",
+		Short: "对比当前版本模板的生成结果与已有项目",
+		Long: `按 .archi-gen.yaml 中记录的项目配置和聚合，在内存中用当前版本的模板重新生成项目，
+并以统一差异格式（unified diff）输出与项目目录中现有文件的差异，不修改任何文件。
+
+差异以项目目录为 a/，新生成的内容为 b/，可以直接用于代码评审或 git apply。
+项目中存在但不再生成的文件不会输出。`,
+		Example: `  archi-gen diff
+  archi-gen diff --dir ./my-project > generator.patch
+  archi-gen diff --stat --exit-code`,
+		Args: cobra.NoArgs,
+		// --exit-code 存在差异时返回错误只是为了设置退出码，不需要打印用法
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runDiff(opts)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.projectDir, "dir", "d", ".", "项目根目录")
+	cmd.Flags().IntVarP(&opts.context, "unified", "U", diff.DefaultContext, "差异上下文行数")
+	cmd.Flags().BoolVar(&opts.stat, "stat", false, "只列出存在差异的文件")
+	cmd.Flags().BoolVar(&opts.exitCode, "exit-code", false, "存在差异时以非零状态码退出")
+
+	return cmd
+}
+
+func runDiff(opts *diffOptions) error {
+	proj, err := loadProject(opts.projectDir)
+	if err != nil {
+		return fmt.Errorf("读取项目配置失败: %w", err)
+	}
+
+	templates, err := proj.resolveTemplates()
+	if err != nil {
+		return err
+	}
+	if err := proj.resolveVersions(); err != nil {
+		return err
+	}
+
+	rendered := output.NewMemFS()
+	if _, err := generator.RenderProject(proj.config, proj.manifest, rendered, templates); err != nil {
+		return fmt.Errorf("重新生成项目失败: %w", err)
+	}
+
+	changed := 0
+	for _, path := range rendered.Paths() {
+		// 清单和快照由工具维护，不参与对比
+		if path == manifest.FileName || strings.HasPrefix(path, manifest.BaseDir+"/") {
+			continue
+		}
+		updated, err := rendered.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		fromName := "a/" + path
+		current, err := os.ReadFile(filepath.Join(proj.dir, filepath.FromSlash(path)))
+		switch {
+		case os.IsNotExist(err):
+			fromName = "/dev/null"
+		case err != nil:
+			return err
+		case bytes.Equal(current, updated):
+			continue
+		}
+
+		changed++
+		if opts.stat {
+			status := "M"
+			if fromName == "/dev/null" {
+				status = "A"
+			}
+			fmt.Printf("%s %s\n", status, path)
+			continue
+		}
+		fmt.Print(diff.Unified(fromName, "b/"+path, string(current), string(updated), opts.context))
+	}
+
+	if opts.stat {
+		fmt.Printf("\n%d 个文件存在差异\n", changed)
+	}
+	if opts.exitCode && changed > 0 {
+		return fmt.Errorf("%d 个文件存在差异", changed)
+	}
+	return nil
+}
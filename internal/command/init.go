@@ -1,19 +1,53 @@
 package command
 
 import (
+	"context"
 	"fmt"
+	"io"
+	"io/fs"
 	"os"
+	"os/signal"
 	"path/filepath"
+	"strings"
+	"syscall"
 
 	"github.com/spf13/cobra"
 	"github.com/tuza/scaffolding-code-generation/internal/config"
 	"github.com/tuza/scaffolding-code-generation/internal/generator"
+	"github.com/tuza/scaffolding-code-generation/internal/manifest"
+	"github.com/tuza/scaffolding-code-generation/internal/output"
 	"github.com/tuza/scaffolding-code-generation/internal/prompt"
+	"golang.org/x/term"
 )
 
+// initOptions init 命令参数
+type initOptions struct {
+	projectName string
+	modulePath  string
+	useRedis    bool
+	database    string
+	framework   string
+	outputPath  string
+	language    string
+	configFile  string
+	yes         bool
+	dryRun      bool
+	format      string
+}
+
+// streamOutput --output 为 "-" 时将项目以归档形式输出到标准输出
+const streamOutput = "-"
+
+// stream 判断是否将项目以归档形式输出到标准输出
+func (o *initOptions) stream() bool {
+	return o.outputPath == streamOutput
+}
+
 // NewInitCommand 创建 init 命令
 func NewInitCommand() *cobra.Command {
-	return &cobra.Command{
+	opts := &initOptions{}
+
+	cmd := &cobra.Command{
 		Use:   "init",
 		Short: "初始化一个新的 DDD 项目",
 		Long: `初始化一个基于领域驱动设计（DDD）的 Go 项目。
@@ -24,27 +58,114 @@ func NewInitCommand() *cobra.Command {
   - 用户模块示例 (user/domain + user/infrastructure)
   - API 模块 (api/user-api)
   - 主程序入口 (cmd/api)
-  - Docker 配置文件`,
-		Example: `  archi-gen init`,
-		RunE:    runInit,
+  - 发件箱投递进程 (cmd/outbox-relay)
+  - Docker 配置文件
+
+配置项可以通过命令行参数或 --config 指定的 YAML 文件提供（命令行参数优先），
+只有仍然缺失的配置项才会交互式询问。使用 --yes 或在非终端环境（如脚本、CI）中运行时
+不会进行交互：可选项使用默认值，缺少必填项时直接报错。
+
+使用 --dry-run 时项目只在内存中生成，输出将要生成的文件树及文件大小，不写入磁盘。
+使用 --output - 时项目以归档（--format tar.gz 或 zip）形式输出到标准输出，进度信息输出到标准错误，
+此时不会进行交互。`,
+		Example: `  archi-gen init
+  archi-gen init --name my-project --module github.com/username/my-project
+  archi-gen init --name my-project --redis=false --output /tmp --yes
+  archi-gen init --name my-project --database mysql --yes
+  archi-gen init --name my-project --framework gin --yes
+  archi-gen init --config archi.yaml
+  archi-gen init --name my-project --yes --dry-run
+  archi-gen init --name my-project --yes --output - | tar xz -C /tmp
+  archi-gen init --name my-project --yes --output - --format zip > my-project.zip`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runInit(cmd, opts)
+		},
 	}
+
+	cmd.Flags().StringVarP(&opts.projectName, "name", "n", "", "项目名称")
+	cmd.Flags().StringVarP(&opts.modulePath, "module", "m", "", "Go 模块路径 (例如: github.com/username/project)")
+	cmd.Flags().BoolVar(&opts.useRedis, "redis", true, "是否使用 Redis")
+	cmd.Flags().StringVar(&opts.database, "database", "", "数据库 (postgres, mysql, sqlite)，默认 postgres")
+	cmd.Flags().StringVar(&opts.framework, "framework", "", "HTTP 框架 (hertz, gin, chi)，默认 hertz")
+	cmd.Flags().StringVarP(&opts.outputPath, "output", "o", "", "项目生成路径（默认当前目录），- 表示以归档形式输出到标准输出")
+	cmd.Flags().StringVarP(&opts.language, "language", "l", "", "开发语言 (go)")
+	cmd.Flags().StringVarP(&opts.configFile, "config", "c", "", "项目配置文件 (例如: archi.yaml)")
+	cmd.Flags().BoolVarP(&opts.yes, "yes", "y", false, "不进行交互，缺失的可选项使用默认值")
+	cmd.Flags().BoolVar(&opts.dryRun, "dry-run", false, "只输出将要生成的文件树，不写入磁盘")
+	cmd.Flags().StringVar(&opts.format, "format", string(output.FormatTarGz), "--output - 时的归档格式 (tar.gz, zip)")
+
+	return cmd
 }
 
-func runInit(cmd *cobra.Command, args []string) error {
-	fmt.Println()
-	fmt.Println("🚀 欢迎使用 Archi-Gen 项目脚手架!")
-	fmt.Println()
-	fmt.Println("   该工具将帮助你创建一个基于 DDD 的 Go 项目")
-	fmt.Println("   技术栈: Go + Hertz + Kitex + GORM + PostgreSQL + Docker")
-	fmt.Println()
+// presetOptions 合并配置文件与命令行参数，命令行参数优先
+func (o *initOptions) presetOptions(cmd *cobra.Command) (*config.Options, error) {
+	preset := &config.Options{}
+	if o.configFile != "" {
+		fileOpts, err := config.LoadOptions(o.configFile)
+		if err != nil {
+			return nil, fmt.Errorf("读取配置文件失败: %w", err)
+		}
+		preset.Merge(fileOpts)
+	}
+
+	flagOpts := &config.Options{
+		ProjectName: o.projectName,
+		ModulePath:  o.modulePath,
+		OutputPath:  o.outputPath,
+		Language:    config.Language(o.language),
+		Database:    o.database,
+		Framework:   o.framework,
+	}
+	if o.stream() {
+		// 输出到标准输出时不需要输出路径
+		flagOpts.OutputPath = ""
+	}
+	if cmd.Flags().Changed("redis") {
+		flagOpts.UseRedis = &o.useRedis
+	}
+	preset.Merge(flagOpts)
 
-	// 创建交互式问答
-	interactive := prompt.NewInteractive()
+	return preset, nil
+}
 
-	// 获取用户配置
-	cfg, err := interactive.AskProjectConfig()
+func runInit(cmd *cobra.Command, opts *initOptions) error {
+	preset, err := opts.presetOptions(cmd)
 	if err != nil {
-		return fmt.Errorf("获取配置失败: %w", err)
+		return err
+	}
+
+	var format output.ArchiveFormat
+	if opts.stream() {
+		if format, err = output.ParseArchiveFormat(opts.format); err != nil {
+			return err
+		}
+		if term.IsTerminal(int(os.Stdout.Fd())) {
+			return fmt.Errorf("标准输出是终端，请将归档重定向到文件或管道")
+		}
+	}
+
+	// 非终端环境无法交互，与 --yes 一样直接使用预设配置；标准输出用于归档时同样不交互
+	interactive := !opts.yes && !opts.stream() && term.IsTerminal(int(os.Stdin.Fd()))
+
+	var cfg *config.ProjectConfig
+	if interactive {
+		fmt.Println()
+		fmt.Println("🚀 欢迎使用 Archi-Gen 项目脚手架!")
+		fmt.Println()
+		fmt.Println("   该工具将帮助你创建一个基于 DDD 的 Go 项目")
+		fmt.Println("   技术栈: Go + Hertz/Gin/chi + Kitex + GORM (PostgreSQL/MySQL/SQLite) + Docker")
+		fmt.Println()
+
+		// 询问缺失的配置
+		cfg, err = prompt.NewInteractive().AskProjectConfig(preset)
+		if err != nil {
+			return fmt.Errorf("获取配置失败: %w", err)
+		}
+	} else {
+		cfg, err = preset.Resolve(opts.yes)
+		if err != nil {
+			return err
+		}
 	}
 
 	// 验证配置
@@ -52,6 +173,23 @@ func runInit(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("配置验证失败: %w", err)
 	}
 
+	templates, templatesName, err := resolveTemplates(filepath.Join(cfg.OutputPath, cfg.ProjectName), "")
+	if err != nil {
+		return err
+	}
+	versions, err := resolveVersions(nil)
+	if err != nil {
+		return err
+	}
+	src := &initSources{templates: templates, templatesName: templatesName, versions: versions}
+
+	if opts.dryRun {
+		return runInitDryRun(cfg, src)
+	}
+	if opts.stream() {
+		return runInitStream(cfg, format, src)
+	}
+
 	// 检查目录是否已存在
 	projectFullPath := filepath.Join(cfg.OutputPath, cfg.ProjectName)
 	if _, err := os.Stat(projectFullPath); !os.IsNotExist(err) {
@@ -71,6 +209,14 @@ func runInit(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("不支持的语言: %s", cfg.Language)
 	}
 
+	// Ctrl-C 时在当前步骤完成后停止，并删除已生成的临时目录
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	gen.SetContext(ctx)
+	if err := src.apply(gen); err != nil {
+		return err
+	}
+
 	if err := gen.Generate(); err != nil {
 		return fmt.Errorf("生成项目失败: %w", err)
 	}
@@ -81,6 +227,67 @@ func runInit(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// initSources 生成新项目使用的模板和依赖版本
+type initSources struct {
+	templates     fs.FS
+	templatesName string
+	versions      map[string]string
+}
+
+// apply 为生成器设置模板和依赖版本
+func (s *initSources) apply(gen generator.Generator) error {
+	gen.SetTemplates(s.templates, s.templatesName)
+	return gen.SetVersions(s.versions)
+}
+
+// runInitDryRun 在内存中生成项目并输出文件树
+func runInitDryRun(cfg *config.ProjectConfig, src *initSources) error {
+	mem := output.NewMemFS()
+	gen := generator.NewGoGenerator(cfg)
+	if err := src.apply(gen); err != nil {
+		return err
+	}
+	gen.SetFS(mem)
+	gen.SetOutput(io.Discard)
+	if err := gen.Generate(); err != nil {
+		return fmt.Errorf("生成项目失败: %w", err)
+	}
+
+	fmt.Println()
+	if err := output.WriteTree(os.Stdout, cfg.ProjectName, mem, manifest.BaseDir); err != nil {
+		return err
+	}
+	fmt.Println()
+	fmt.Printf("ℹ️  dry-run 模式，未写入任何文件（目标路径: %s）\n", filepath.Join(cfg.OutputPath, cfg.ProjectName))
+	fmt.Println()
+	return nil
+}
+
+// runInitStream 将项目以归档形式输出到标准输出，进度信息输出到标准错误
+func runInitStream(cfg *config.ProjectConfig, format output.ArchiveFormat, src *initSources) error {
+	archive, err := output.NewArchiveFS(os.Stdout, format, cfg.ProjectName)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(os.Stderr, "✨ 正在生成项目 %s (%s)...\n", cfg.ProjectName, format)
+	gen := generator.NewGoGenerator(cfg)
+	if err := src.apply(gen); err != nil {
+		return err
+	}
+	gen.SetFS(archive)
+	gen.SetOutput(os.Stderr)
+	if err := gen.Generate(); err != nil {
+		return fmt.Errorf("生成项目失败: %w", err)
+	}
+	if err := archive.Close(); err != nil {
+		return fmt.Errorf("写入归档失败: %w", err)
+	}
+
+	fmt.Fprintln(os.Stderr, "🎉 项目骨架生成成功!")
+	return nil
+}
+
 // printConfigSummary 打印配置摘要
 func printConfigSummary(cfg *config.ProjectConfig) {
 	fmt.Println()
@@ -89,7 +296,8 @@ func printConfigSummary(cfg *config.ProjectConfig) {
 	fmt.Printf("   模块路径: %s\n", cfg.ModulePath)
 	fmt.Printf("   生成路径: %s\n", filepath.Join(cfg.OutputPath, cfg.ProjectName))
 	fmt.Printf("   开发语言: %s\n", cfg.Language)
-	fmt.Printf("   数据库:   PostgreSQL\n")
+	fmt.Printf("   HTTP 框架: %s\n", config.FrameworkName(cfg.Framework))
+	fmt.Printf("   数据库:   %s\n", config.DatabaseName(cfg.Database))
 	fmt.Printf("   缓存:     %s\n", boolToYesNo(cfg.UseRedis))
 	fmt.Printf("   部署方式: Docker\n")
 }
@@ -105,12 +313,18 @@ func printSuccessMessage(cfg *config.ProjectConfig) {
 	fmt.Println("🚀 快速开始:")
 	fmt.Printf("   cd %s\n", projectFullPath)
 	fmt.Println("   go work sync")
+	// SQLite 不需要数据库服务
+	var services []string
+	if cfg.Database != config.DatabaseSQLite {
+		services = append(services, cfg.Database)
+	}
 	if cfg.UseRedis {
-		fmt.Println("   docker-compose up -d postgres redis")
-	} else {
-		fmt.Println("   docker-compose up -d postgres")
+		services = append(services, "redis")
+	}
+	if len(services) > 0 {
+		fmt.Printf("   docker compose up -d %s\n", strings.Join(services, " "))
 	}
-	fmt.Println("   go run ./cmd/api/main.go")
+	fmt.Println("   go run ./cmd/api")
 	fmt.Println()
 	fmt.Println("📖 访问 http://localhost:8080/health 检查服务状态")
 	fmt.Println()
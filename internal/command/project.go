@@ -0,0 +1,71 @@
+package command
+
+import (
+	"fmt"
+	"io/fs"
+	"path/filepath"
+
+	"github.com/tuza/scaffolding-code-generation/internal/config"
+	"github.com/tuza/scaffolding-code-generation/internal/generator"
+	"github.com/tuza/scaffolding-code-generation/internal/manifest"
+)
+
+// project 已有项目
+type project struct {
+	dir      string                // 项目根目录（绝对路径）
+	config   *config.ProjectConfig // 项目配置
+	manifest *manifest.Manifest    // 项目清单
+}
+
+// loadProject 读取已有项目的配置和清单
+// 优先使用 .archi-gen.yaml；旧版本生成的项目没有清单时从项目文件推断配置，并创建新的清单
+func loadProject(dir string) (*project, error) {
+	projectDir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	if manifest.Exists(projectDir) {
+		m, err := manifest.Load(projectDir)
+		if err != nil {
+			return nil, err
+		}
+		cfg, err := m.ProjectConfig(projectDir)
+		if err != nil {
+			return nil, fmt.Errorf("%s 中的项目配置无效: %w", manifest.FileName, err)
+		}
+		return &project{dir: projectDir, config: cfg, manifest: m}, nil
+	}
+
+	cfg, err := config.LoadFromProject(projectDir)
+	if err != nil {
+		return nil, err
+	}
+	m := manifest.New(cfg)
+	modules, err := generator.WorkspaceModules(projectDir)
+	if err != nil {
+		return nil, err
+	}
+	m.AddModules(modules...)
+	return &project{dir: projectDir, config: cfg, manifest: m}, nil
+}
+
+// resolveTemplates 确定项目使用的模板，并将模板集名称记录到清单中
+func (p *project) resolveTemplates() (fs.FS, error) {
+	root, name, err := resolveTemplates(p.dir, p.manifest.Templates)
+	if err != nil {
+		return nil, err
+	}
+	p.manifest.Templates = name
+	return root, nil
+}
+
+// resolveVersions 确定项目使用的依赖版本，并记录到清单中
+func (p *project) resolveVersions() error {
+	versions, err := resolveVersions(p.manifest.Versions)
+	if err != nil {
+		return err
+	}
+	p.manifest.Versions = versions
+	return nil
+}
@@ -0,0 +1,205 @@
+package command
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	"github.com/tuza/scaffolding-code-generation/internal/config"
+	"github.com/tuza/scaffolding-code-generation/internal/manifest"
+	"github.com/tuza/scaffolding-code-generation/internal/template"
+)
+
+// templateDir --template-dir 全局参数
+var templateDir string
+
+// AddGlobalFlags 为根命令添加对所有子命令生效的参数
+func AddGlobalFlags(cmd *cobra.Command) {
+	cmd.PersistentFlags().StringVar(&templateDir, "template-dir", "",
+		"用户模板目录，按相对路径覆盖或新增内置模板（默认读取全局配置中的 template_dir）")
+	cmd.PersistentFlags().StringVar(&versionsFile, "versions", "",
+		"依赖版本文件，覆盖内置版本目录中的版本（默认读取全局配置中的 versions）")
+}
+
+// resolveTemplates 确定本次使用的模板，优先级：--template-dir > 全局配置 > 项目清单中记录的模板目录
+// projectDir 为项目根目录，recorded 为清单中记录的模板集（新项目为空），返回模板根目录及记录到清单中的名称
+func resolveTemplates(projectDir, recorded string) (fs.FS, string, error) {
+	dir, err := resolveTemplateDir(projectDir, recorded)
+	if err != nil {
+		return nil, "", err
+	}
+	if dir == "" {
+		return template.Builtin(), manifest.TemplatesBuiltin, nil
+	}
+
+	root, err := template.Load(dir)
+	if err != nil {
+		return nil, "", err
+	}
+	return root, templatesName(projectDir, dir), nil
+}
+
+// resolveTemplateDir 确定用户模板目录（绝对路径），没有用户模板目录时返回空字符串
+func resolveTemplateDir(projectDir, recorded string) (string, error) {
+	dir := templateDir
+	if dir == "" {
+		global, err := config.LoadGlobalConfig()
+		if err != nil {
+			return "", err
+		}
+		dir = global.TemplateDir
+	}
+	if dir == "" && recorded != "" && recorded != manifest.TemplatesBuiltin {
+		// 清单中记录的是相对项目根目录的路径，旧版本记录的绝对路径同样支持
+		recordedDir := filepath.FromSlash(recorded)
+		if !filepath.IsAbs(recordedDir) {
+			recordedDir = filepath.Join(projectDir, recordedDir)
+		}
+		if _, err := os.Stat(recordedDir); err != nil {
+			return "", fmt.Errorf("项目使用的模板目录 '%s' 不存在，请通过 --template-dir 指定: %w", recorded, err)
+		}
+		dir = recordedDir
+	}
+	if dir == "" {
+		return "", nil
+	}
+	return filepath.Abs(dir)
+}
+
+// templatesName 返回记录到清单中的模板目录名称：相对项目根目录的路径，
+// 使清单不依赖项目所在的机器和位置；无法表示为相对路径时（例如位于不同的盘符）使用绝对路径
+func templatesName(projectDir, absDir string) string {
+	absProjectDir, err := filepath.Abs(projectDir)
+	if err != nil {
+		return filepath.ToSlash(absDir)
+	}
+	rel, err := filepath.Rel(absProjectDir, absDir)
+	if err != nil {
+		return filepath.ToSlash(absDir)
+	}
+	if rel == manifest.TemplatesBuiltin {
+		// 与内置模板集的名称区分开
+		rel = "." + string(filepath.Separator) + rel
+	}
+	return filepath.ToSlash(rel)
+}
+
+// NewTemplatesCommand 创建 templates 命令
+func NewTemplatesCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "templates",
+		Short: "管理生成项目使用的模板",
+	}
+
+	cmd.AddCommand(newTemplatesExportCommand())
+	cmd.AddCommand(newTemplatesLintCommand())
+
+	return cmd
+}
+
+// newTemplatesExportCommand 创建 templates export 命令
+func newTemplatesExportCommand() *cobra.Command {
+	var force bool
+
+	cmd := &cobra.Command{
+		Use:   "export <dir>",
+		Short: "导出内置模板，作为自定义模板的起点",
+		Long: `将内置模板导出到指定目录，目录结构与 --template-dir 要求的结构一致：
+
+  go/project/    init 生成的项目骨架
+  go/aggregate/  add aggregate / add entity 生成的聚合模块
+  go/snippets/   插入 cmd/api/main.go 等已有文件的代码片段
+  go/http/       各 HTTP 框架（hertz、gin、chi）专属的处理器、错误处理和入口模板
+
+模板目录中只需要保留需要修改或新增的文件，未修改的文件建议删除，
+以便继续使用新版本 archi-gen 中对应内置模板的改进。`,
+		Example: `  archi-gen templates export ./archi-templates
+  archi-gen --template-dir ./archi-templates init --name my-project`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			names, err := template.Export(args[0], force)
+			if err != nil {
+				return fmt.Errorf("导出模板失败: %w", err)
+			}
+			fmt.Printf("📦 已导出 %d 个模板到 %s\n", len(names), args[0])
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&force, "force", false, "覆盖目录中已存在的文件")
+
+	return cmd
+}
+
+// newTemplatesLintCommand 创建 templates lint 命令
+func newTemplatesLintCommand() *cobra.Command {
+	var verbose bool
+
+	cmd := &cobra.Command{
+		Use:   "lint",
+		Short: "检查模板的语法和渲染错误",
+		Long: `解析全部模板（内置模板叠加 --template-dir 或全局配置中的用户模板），
+并以一组配置组合渲染每个模板：Redis 开/关 × 每种数据库 × 每种 HTTP 框架，
+聚合模板还会分别以默认实体、覆盖全部字段类型的实体和自增主键实体渲染。
+
+发现的问题以 文件:行号: 错误信息 的格式输出，存在问题时以非零状态退出。`,
+		Example: `  archi-gen templates lint
+  archi-gen --template-dir ./archi-templates templates lint -v`,
+		Args: cobra.NoArgs,
+		// 存在问题时返回错误只是为了设置退出码，不需要打印用法
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runTemplatesLint(verbose)
+		},
+	}
+
+	cmd.Flags().BoolVarP(&verbose, "verbose", "v", false, "列出出现问题的全部配置组合")
+
+	return cmd
+}
+
+func runTemplatesLint(verbose bool) error {
+	dir, err := resolveTemplateDir("", "")
+	if err != nil {
+		return err
+	}
+	root, err := template.Open(dir)
+	if err != nil {
+		return err
+	}
+	cases, err := template.LintMatrix()
+	if err != nil {
+		return err
+	}
+
+	issues, err := template.NewEngine().Lint(root, cases)
+	if err != nil {
+		return fmt.Errorf("检查模板失败: %w", err)
+	}
+
+	source := "内置模板"
+	if dir != "" {
+		source = "内置模板 + " + dir
+	}
+	if len(issues) == 0 {
+		fmt.Printf("✔ %s 检查通过（%d 个配置组合）\n", source, len(cases))
+		return nil
+	}
+
+	for _, issue := range issues {
+		fmt.Println(issue)
+		switch {
+		case len(issue.Cases) == 0:
+		case verbose:
+			for _, c := range issue.Cases {
+				fmt.Printf("    %s\n", c)
+			}
+		default:
+			fmt.Printf("    出现于 %d 个配置组合，例如 %s\n", len(issue.Cases), issue.Cases[0])
+		}
+	}
+	fmt.Println()
+	return fmt.Errorf("%s 中发现 %d 个问题", source, len(issues))
+}
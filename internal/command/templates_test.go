@@ -0,0 +1,81 @@
+package command
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/tuza/scaffolding-code-generation/internal/config"
+	"github.com/tuza/scaffolding-code-generation/internal/manifest"
+)
+
+func TestResolveTemplates(t *testing.T) {
+	// 不读取本机的全局配置
+	t.Setenv(config.GlobalConfigEnv, filepath.Join(t.TempDir(), "config.yaml"))
+	t.Cleanup(func() { templateDir = "" })
+
+	root := t.TempDir()
+	projectDir := filepath.Join(root, "work", "demo")
+	userDir := filepath.Join(root, "templates")
+	if err := os.MkdirAll(filepath.Join(userDir, "go", "project"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(userDir, "go", "project", "NOTES.md.tmpl"), []byte("user notes"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name        string
+		templateDir string // --template-dir
+		recorded    string // 清单中记录的模板集
+		wantName    string
+		wantUser    bool // 是否叠加了用户模板
+	}{
+		{name: "内置模板", wantName: manifest.TemplatesBuiltin},
+		{name: "清单记录内置模板", recorded: manifest.TemplatesBuiltin, wantName: manifest.TemplatesBuiltin},
+		// 清单中记录相对项目根目录的路径，不包含本机的绝对路径
+		{name: "--template-dir", templateDir: userDir, wantName: "../../templates", wantUser: true},
+		{name: "清单记录相对路径", recorded: "../../templates", wantName: "../../templates", wantUser: true},
+		// 旧版本记录的绝对路径仍然可用，并改为记录相对路径
+		{name: "清单记录绝对路径", recorded: userDir, wantName: "../../templates", wantUser: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			templateDir = tt.templateDir
+			templates, name, err := resolveTemplates(projectDir, tt.recorded)
+			if err != nil {
+				t.Fatalf("resolveTemplates() error = %v", err)
+			}
+			if name != tt.wantName {
+				t.Errorf("name = %q, want %q", name, tt.wantName)
+			}
+			_, err = fs.Stat(templates, "go/project/NOTES.md.tmpl")
+			if got := err == nil; got != tt.wantUser {
+				t.Errorf("用户模板可见 = %v, want %v", got, tt.wantUser)
+			}
+		})
+	}
+
+	templateDir = ""
+	if _, _, err := resolveTemplates(projectDir, "../missing"); err == nil {
+		t.Error("记录的模板目录不存在时应返回错误")
+	}
+}
+
+func TestTemplatesName(t *testing.T) {
+	projectDir := filepath.Join(t.TempDir(), "demo")
+	tests := []struct {
+		dir  string
+		want string
+	}{
+		{filepath.Join(projectDir, "templates"), "templates"},
+		{filepath.Join(filepath.Dir(projectDir), "templates"), "../templates"},
+		{filepath.Join(projectDir, manifest.TemplatesBuiltin), "./" + manifest.TemplatesBuiltin},
+	}
+	for _, tt := range tests {
+		if got := templatesName(projectDir, tt.dir); got != tt.want {
+			t.Errorf("templatesName(%q) = %q, want %q", tt.dir, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,140 @@
+package command
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/tuza/scaffolding-code-generation/internal/manifest"
+	"github.com/tuza/scaffolding-code-generation/internal/upgrade"
+)
+
+// upgradeOptions upgrade 命令参数
+type upgradeOptions struct {
+	projectDir string
+	dryRun     bool
+	reject     bool
+	verbose    bool
+}
+
+// NewUpgradeCommand 创建 upgrade 命令
+func NewUpgradeCommand() *cobra.Command {
+	opts := &upgradeOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "upgrade",
+		Short: "使用当前版本的模板升级已有项目",
+		Long: `按 .archi-gen.yaml 中记录的项目配置和聚合，用当前版本的模板重新生成项目，
+并对每个文件进行三方比较：原始生成版本（.archi-gen/base 快照）、新生成版本和当前版本。
+
+  - 用户未修改的文件直接替换为新版本
+  - 只有用户修改的文件保持不变
+  - 双方都修改的文件自动合并，冲突部分写入冲突标记（使用 --reject 时改为写入 .rej 文件）
+  - 没有快照且已被修改的文件（旧版本生成的项目），新版本与当前版本的差异写入 .rej 文件
+
+升级完成后清单和快照会更新为新版本，建议在干净的 git 工作区中执行并检查结果。`,
+		Example: `  archi-gen upgrade
+  archi-gen upgrade --dir ./my-project --dry-run
+  archi-gen upgrade --reject`,
+		Args: cobra.NoArgs,
+		// 存在冲突时返回错误只是为了设置退出码，不需要打印用法
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runUpgrade(opts)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.projectDir, "dir", "d", ".", "项目根目录")
+	cmd.Flags().BoolVar(&opts.dryRun, "dry-run", false, "只显示升级结果，不修改任何文件")
+	cmd.Flags().BoolVar(&opts.reject, "reject", false, "冲突时保留当前文件，将新模板的修改写入 .rej 文件")
+	cmd.Flags().BoolVarP(&opts.verbose, "verbose", "v", false, "同时列出未变化的文件")
+
+	return cmd
+}
+
+func runUpgrade(opts *upgradeOptions) error {
+	proj, err := loadProject(opts.projectDir)
+	if err != nil {
+		return fmt.Errorf("读取项目配置失败: %w", err)
+	}
+	if !manifest.Exists(proj.dir) {
+		fmt.Printf("⚠️  项目中没有 %s，配置从项目文件推断，所有与新模板不同的文件都将写入 .rej\n", manifest.FileName)
+	}
+
+	templates, err := proj.resolveTemplates()
+	if err != nil {
+		return err
+	}
+	if err := proj.resolveVersions(); err != nil {
+		return err
+	}
+
+	upgrader := upgrade.New(proj.dir, proj.config, proj.manifest, upgrade.Options{
+		DryRun:    opts.dryRun,
+		Reject:    opts.reject,
+		Templates: templates,
+	})
+
+	fmt.Println()
+	fmt.Printf("⬆️  正在升级项目 %s (%s -> 当前版本)...\n", proj.config.ProjectName, proj.manifest.ToolVersion)
+	fmt.Println()
+
+	report, err := upgrader.Run()
+	if err != nil {
+		return fmt.Errorf("升级失败: %w", err)
+	}
+
+	printUpgradeReport(report, opts.verbose)
+
+	if opts.dryRun {
+		fmt.Println("ℹ️  dry-run 模式，未修改任何文件")
+		fmt.Println()
+	}
+	if report.Count(upgrade.ActionConflict) > 0 || report.Count(upgrade.ActionRejected) > 0 {
+		return fmt.Errorf("存在需要手动处理的冲突，请解决冲突标记或 .rej 文件中的修改")
+	}
+	return nil
+}
+
+// upgradeActionSymbols 升级结果在报告中的标记
+var upgradeActionSymbols = map[upgrade.Action]string{
+	upgrade.ActionUnchanged: " ",
+	upgrade.ActionUpdated:   "U",
+	upgrade.ActionKept:      "K",
+	upgrade.ActionMerged:    "M",
+	upgrade.ActionConflict:  "C",
+	upgrade.ActionRejected:  "R",
+	upgrade.ActionAdded:     "A",
+	upgrade.ActionSkipped:   "S",
+	upgrade.ActionObsolete:  "O",
+}
+
+// printUpgradeReport 打印升级报告
+func printUpgradeReport(report *upgrade.Report, verbose bool) {
+	for _, f := range report.Files {
+		if f.Action == upgrade.ActionUnchanged && !verbose {
+			continue
+		}
+		switch f.Action {
+		case upgrade.ActionConflict:
+			fmt.Printf("   %s %s (%d 处冲突)\n", upgradeActionSymbols[f.Action], f.Path, f.Conflicts)
+		case upgrade.ActionRejected:
+			fmt.Printf("   %s %s -> %s%s\n", upgradeActionSymbols[f.Action], f.Path, f.Path, upgrade.RejectSuffix)
+		default:
+			fmt.Printf("   %s %s\n", upgradeActionSymbols[f.Action], f.Path)
+		}
+	}
+
+	fmt.Println()
+	fmt.Println("📋 升级结果:")
+	fmt.Printf("   版本:     %s -> %s\n", report.FromVersion, report.ToVersion)
+	fmt.Printf("   未变化:   %d\n", report.Count(upgrade.ActionUnchanged))
+	fmt.Printf("   已更新:   %d (U)\n", report.Count(upgrade.ActionUpdated))
+	fmt.Printf("   已合并:   %d (M)\n", report.Count(upgrade.ActionMerged))
+	fmt.Printf("   保留修改: %d (K)\n", report.Count(upgrade.ActionKept))
+	fmt.Printf("   新增:     %d (A)\n", report.Count(upgrade.ActionAdded))
+	fmt.Printf("   冲突:     %d (C)\n", report.Count(upgrade.ActionConflict))
+	fmt.Printf("   .rej:     %d (R)\n", report.Count(upgrade.ActionRejected))
+	fmt.Printf("   已删除:   %d (S)\n", report.Count(upgrade.ActionSkipped))
+	fmt.Printf("   已废弃:   %d (O)\n", report.Count(upgrade.ActionObsolete))
+	fmt.Println()
+}
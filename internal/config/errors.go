@@ -3,6 +3,14 @@ package config
 import "errors"
 
 var (
-	ErrProjectNameEmpty = errors.New("项目名称不能为空")
-	ErrModulePathEmpty  = errors.New("模块路径不能为空")
+	ErrProjectNameEmpty     = errors.New("项目名称不能为空")
+	ErrProjectNameInvalid   = errors.New("项目名称必须以字母开头，只能包含字母、数字、下划线和中划线")
+	ErrModulePathEmpty      = errors.New("模块路径不能为空")
+	ErrModulePathInvalid    = errors.New("模块路径格式不正确，应类似: github.com/username/project")
+	ErrOutputPathEmpty      = errors.New("输出路径不能为空")
+	ErrLanguageUnsupported  = errors.New("不支持的开发语言")
+	ErrDatabaseUnsupported  = errors.New("不支持的数据库")
+	ErrFrameworkUnsupported = errors.New("不支持的 HTTP 框架")
+	ErrMissingValue         = errors.New("缺少必填配置")
+	ErrNotArchiProject      = errors.New("不是 archi-gen 生成的项目")
 )
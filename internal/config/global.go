@@ -0,0 +1,80 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+// GlobalConfigEnv 指定全局配置文件路径的环境变量
+const GlobalConfigEnv = "ARCHI_GEN_CONFIG"
+
+// GlobalConfig 全局配置，对所有项目生效
+//
+// 配置文件格式 ($XDG_CONFIG_HOME/archi-gen/config.yaml):
+//
+//	template_dir: ~/company/archi-templates
+//	versions: ~/company/archi-versions.yaml
+type GlobalConfig struct {
+	TemplateDir string `yaml:"template_dir"` // 用户模板目录，覆盖同名的内置模板
+	Versions    string `yaml:"versions"`     // 依赖版本文件，覆盖内置版本目录中的版本
+}
+
+// GlobalConfigPath 返回全局配置文件路径，优先使用 ARCHI_GEN_CONFIG 环境变量
+func GlobalConfigPath() (string, error) {
+	if path := os.Getenv(GlobalConfigEnv); path != "" {
+		return path, nil
+	}
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "archi-gen", "config.yaml"), nil
+}
+
+// LoadGlobalConfig 读取全局配置，配置文件不存在时返回空配置
+// 相对的模板目录和版本文件路径以配置文件所在目录为基准，~ 展开为用户主目录
+func LoadGlobalConfig() (*GlobalConfig, error) {
+	path, err := GlobalConfigPath()
+	if err != nil {
+		return &GlobalConfig{}, nil
+	}
+	content, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return &GlobalConfig{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var cfg GlobalConfig
+	if err := yaml.Unmarshal(content, &cfg); err != nil {
+		return nil, fmt.Errorf("解析全局配置 '%s' 失败: %w", path, err)
+	}
+	for _, p := range []*string{&cfg.TemplateDir, &cfg.Versions} {
+		if *p == "" {
+			continue
+		}
+		if *p, err = expandPath(*p, filepath.Dir(path)); err != nil {
+			return nil, err
+		}
+	}
+	return &cfg, nil
+}
+
+// expandPath 展开 ~ 并将相对路径转换为以 base 为基准的绝对路径
+func expandPath(path, base string) (string, error) {
+	if path == "~" || len(path) > 1 && path[:2] == "~/" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		path = filepath.Join(home, path[1:])
+	}
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(base, path)
+	}
+	return path, nil
+}
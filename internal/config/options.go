@@ -0,0 +1,136 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Options 预设的项目配置（来自命令行参数或配置文件），未设置的字段为零值
+//
+// 配置文件格式 (archi.yaml):
+//
+//	name: my-project
+//	module: github.com/username/my-project
+//	redis: true
+//	database: mysql
+//	framework: gin
+//	output: /home/user/projects
+//	language: go
+type Options struct {
+	ProjectName string   `yaml:"name"`      // 项目名称
+	ModulePath  string   `yaml:"module"`    // Go 模块路径
+	UseRedis    *bool    `yaml:"redis"`     // 是否使用 Redis
+	Database    string   `yaml:"database"`  // 数据库 (postgres, mysql, sqlite)
+	Framework   string   `yaml:"framework"` // HTTP 框架 (hertz, gin, chi)
+	OutputPath  string   `yaml:"output"`    // 输出路径
+	Language    Language `yaml:"language"`  // 开发语言
+}
+
+// LoadOptions 从 YAML 配置文件加载预设配置，相对输出路径以配置文件所在目录为基准
+// 配置文件中出现未知的键（通常是拼写错误）时返回错误，而不是静默忽略
+func LoadOptions(path string) (*Options, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var opts Options
+	dec := yaml.NewDecoder(f)
+	dec.KnownFields(true)
+	if err := dec.Decode(&opts); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("解析配置文件 '%s' 失败: %w", path, err)
+	}
+	if opts.OutputPath != "" && !filepath.IsAbs(opts.OutputPath) {
+		opts.OutputPath = filepath.Join(filepath.Dir(path), opts.OutputPath)
+	}
+	return &opts, nil
+}
+
+// Merge 用 other 中已设置的字段覆盖当前配置（other 优先级更高）
+func (o *Options) Merge(other *Options) {
+	if other == nil {
+		return
+	}
+	if other.ProjectName != "" {
+		o.ProjectName = other.ProjectName
+	}
+	if other.ModulePath != "" {
+		o.ModulePath = other.ModulePath
+	}
+	if other.UseRedis != nil {
+		o.UseRedis = other.UseRedis
+	}
+	if other.Database != "" {
+		o.Database = other.Database
+	}
+	if other.Framework != "" {
+		o.Framework = other.Framework
+	}
+	if other.OutputPath != "" {
+		o.OutputPath = other.OutputPath
+	}
+	if other.Language != "" {
+		o.Language = other.Language
+	}
+}
+
+// Apply 将已设置的字段写入项目配置
+func (o *Options) Apply(cfg *ProjectConfig) error {
+	if o.ProjectName != "" {
+		cfg.ProjectName = o.ProjectName
+	}
+	if o.ModulePath != "" {
+		cfg.ModulePath = o.ModulePath
+	}
+	if o.UseRedis != nil {
+		cfg.UseRedis = *o.UseRedis
+	}
+	if o.Database != "" {
+		cfg.Database = o.Database
+	}
+	if o.Framework != "" {
+		cfg.Framework = o.Framework
+	}
+	if o.OutputPath != "" {
+		outputPath, err := filepath.Abs(o.OutputPath)
+		if err != nil {
+			return err
+		}
+		cfg.OutputPath = outputPath
+	}
+	if o.Language != "" {
+		cfg.Language = o.Language
+	}
+	return nil
+}
+
+// Resolve 在不进行交互的情况下生成项目配置
+// 可选项使用默认值；useDefaults 为 true 时模块路径缺省为项目名称，否则视为缺失
+func (o *Options) Resolve(useDefaults bool) (*ProjectConfig, error) {
+	if o.ProjectName == "" {
+		return nil, fmt.Errorf("%w: 项目名称 (--name)", ErrMissingValue)
+	}
+	if o.ModulePath == "" && !useDefaults {
+		return nil, fmt.Errorf("%w: 模块路径 (--module)，或使用 --yes 以项目名称作为模块路径", ErrMissingValue)
+	}
+
+	cfg := NewProjectConfig()
+	cfg.ModulePath = o.ProjectName
+	cfg.UseRedis = true
+	cwd, err := os.Getwd()
+	if err != nil {
+		return nil, err
+	}
+	cfg.OutputPath = cwd
+
+	if err := o.Apply(cfg); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
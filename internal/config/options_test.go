@@ -0,0 +1,88 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLoadOptions(t *testing.T) {
+	off := false
+	tests := []struct {
+		name    string
+		content string
+		want    Options
+		wantErr string // 错误信息中应包含的内容，为空表示应加载成功
+	}{
+		{
+			name:    "全部字段",
+			content: "name: demo\nmodule: example.com/demo\ndatabase: mysql\nredis: false\nlanguage: go\n",
+			want:    Options{ProjectName: "demo", ModulePath: "example.com/demo", Database: DatabaseMySQL, UseRedis: &off, Language: LanguageGo},
+		},
+		{
+			name:    "空文件",
+			content: "",
+		},
+		{
+			name:    "键名拼写错误",
+			content: "name: demo\nmodlue: example.com/demo\n",
+			wantErr: "field modlue not found",
+		},
+		{
+			name:    "database 拼写错误",
+			content: "name: demo\ndatabse: mysql\n",
+			wantErr: "field databse not found",
+		},
+		{
+			name:    "格式错误",
+			content: "name: [demo\n",
+			wantErr: "解析配置文件",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "archi.yaml")
+			if err := os.WriteFile(path, []byte(tt.content), 0644); err != nil {
+				t.Fatal(err)
+			}
+			got, err := LoadOptions(path)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("LoadOptions() error = %v, want error containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("LoadOptions() error = %v", err)
+			}
+			if got.ProjectName != tt.want.ProjectName || got.ModulePath != tt.want.ModulePath || got.Database != tt.want.Database ||
+				got.Language != tt.want.Language || !equalBoolPtr(got.UseRedis, tt.want.UseRedis) {
+				t.Errorf("LoadOptions() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadOptionsRelativeOutput(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "archi.yaml")
+	if err := os.WriteFile(path, []byte("output: projects\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	got, err := LoadOptions(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 相对输出路径以配置文件所在目录为基准
+	if want := filepath.Join(dir, "projects"); got.OutputPath != want {
+		t.Errorf("OutputPath = %q, want %q", got.OutputPath, want)
+	}
+}
+
+func equalBoolPtr(a, b *bool) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
@@ -1,5 +1,17 @@
 package config
 
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"golang.org/x/mod/module"
+)
+
+// projectNamePattern 项目名称格式（只允许字母、数字、下划线、中划线）
+var projectNamePattern = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9_-]*$`)
+
 // Language 开发语言类型
 type Language string
 
@@ -8,24 +20,46 @@ const (
 	LanguageJava Language = "java" // 预留
 )
 
+// 支持的数据库
+const (
+	DatabasePostgres = "postgres"
+	DatabaseMySQL    = "mysql"
+	DatabaseSQLite   = "sqlite"
+)
+
+// Databases 支持的数据库，第一个为默认值
+var Databases = []string{DatabasePostgres, DatabaseMySQL, DatabaseSQLite}
+
+// 支持的 HTTP 框架
+const (
+	FrameworkHertz = "hertz"
+	FrameworkGin   = "gin"
+	FrameworkChi   = "chi" // net/http + chi 路由
+)
+
+// Frameworks 支持的 HTTP 框架，第一个为默认值
+var Frameworks = []string{FrameworkHertz, FrameworkGin, FrameworkChi}
+
 // ProjectConfig 项目配置
 type ProjectConfig struct {
 	// 用户输入的配置
 	ProjectName string   // 项目名称
 	Language    Language // 开发语言
 	UseRedis    bool     // 是否使用 Redis
+	Database    string   // 数据库 (postgres, mysql, sqlite)
+	Framework   string   // HTTP 框架 (hertz, gin, chi)
 	ModulePath  string   // Go 模块路径 (例如: github.com/username/project)
 	OutputPath  string   // 输出路径 (项目生成的目标目录)
 
 	// 固定配置
-	Database   string // 固定为 "postgres"
 	Deployment string // 固定为 "docker"
 }
 
 // NewProjectConfig 创建项目配置，设置默认值
 func NewProjectConfig() *ProjectConfig {
 	return &ProjectConfig{
-		Database:   "postgres",
+		Database:   DatabasePostgres,
+		Framework:  FrameworkHertz,
 		Deployment: "docker",
 		Language:   LanguageGo,
 	}
@@ -33,11 +67,97 @@ func NewProjectConfig() *ProjectConfig {
 
 // Validate 验证配置
 func (c *ProjectConfig) Validate() error {
-	if c.ProjectName == "" {
+	if err := ValidateProjectName(c.ProjectName); err != nil {
+		return err
+	}
+	if err := ValidateModulePath(c.ModulePath); err != nil {
+		return err
+	}
+	if err := ValidateLanguage(c.Language); err != nil {
+		return err
+	}
+	if err := ValidateDatabase(c.Database); err != nil {
+		return err
+	}
+	if err := ValidateFramework(c.Framework); err != nil {
+		return err
+	}
+	if c.OutputPath == "" {
+		return ErrOutputPathEmpty
+	}
+	return nil
+}
+
+// ValidateProjectName 验证项目名称
+func ValidateProjectName(name string) error {
+	if name == "" {
 		return ErrProjectNameEmpty
 	}
-	if c.ModulePath == "" {
+	if !projectNamePattern.MatchString(name) {
+		return fmt.Errorf("%w: '%s'", ErrProjectNameInvalid, name)
+	}
+	return nil
+}
+
+// ValidateModulePath 验证 Go 模块路径
+func ValidateModulePath(path string) error {
+	if path == "" {
 		return ErrModulePathEmpty
 	}
+	if err := module.CheckImportPath(path); err != nil {
+		return fmt.Errorf("%w: %v", ErrModulePathInvalid, err)
+	}
+	return nil
+}
+
+// ValidateLanguage 验证开发语言
+func ValidateLanguage(language Language) error {
+	switch language {
+	case LanguageGo:
+		return nil
+	case LanguageJava:
+		return fmt.Errorf("%w: %s（即将支持）", ErrLanguageUnsupported, language)
+	default:
+		return fmt.Errorf("%w: %s", ErrLanguageUnsupported, language)
+	}
+}
+
+// ValidateDatabase 验证数据库类型
+func ValidateDatabase(database string) error {
+	if !slices.Contains(Databases, database) {
+		return fmt.Errorf("%w: %s（可选: %s）", ErrDatabaseUnsupported, database, strings.Join(Databases, ", "))
+	}
 	return nil
 }
+
+// DatabaseName 返回数据库的显示名称
+func DatabaseName(database string) string {
+	switch database {
+	case DatabaseMySQL:
+		return "MySQL"
+	case DatabaseSQLite:
+		return "SQLite"
+	default:
+		return "PostgreSQL"
+	}
+}
+
+// ValidateFramework 验证 HTTP 框架
+func ValidateFramework(framework string) error {
+	if !slices.Contains(Frameworks, framework) {
+		return fmt.Errorf("%w: %s（可选: %s）", ErrFrameworkUnsupported, framework, strings.Join(Frameworks, ", "))
+	}
+	return nil
+}
+
+// FrameworkName 返回 HTTP 框架的显示名称
+func FrameworkName(framework string) string {
+	switch framework {
+	case FrameworkGin:
+		return "Gin"
+	case FrameworkChi:
+		return "net/http + chi"
+	default:
+		return "Hertz"
+	}
+}
@@ -0,0 +1,63 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/mod/modfile"
+)
+
+// LoadFromProject 从已生成的项目目录中还原项目配置
+func LoadFromProject(projectDir string) (*ProjectConfig, error) {
+	absDir, err := filepath.Abs(projectDir)
+	if err != nil {
+		return nil, err
+	}
+
+	// 以 bom 模块路径推断项目模块路径
+	bomModPath := filepath.Join(absDir, "bom", "go.mod")
+	content, err := os.ReadFile(bomModPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("%w: %s", ErrNotArchiProject, absDir)
+		}
+		return nil, err
+	}
+	bomModule := modfile.ModulePath(content)
+	if !strings.HasSuffix(bomModule, "/bom") {
+		return nil, fmt.Errorf("%w: 无法识别 bom 模块路径 '%s'", ErrNotArchiProject, bomModule)
+	}
+
+	cfg := NewProjectConfig()
+	cfg.ProjectName = filepath.Base(absDir)
+	cfg.ModulePath = strings.TrimSuffix(bomModule, "/bom")
+	cfg.OutputPath = filepath.Dir(absDir)
+
+	// docker-compose 中包含 redis 服务即视为启用 Redis，数据库以其中的数据库服务判断，没有数据库服务时为 SQLite
+	if compose, err := os.ReadFile(filepath.Join(absDir, "docker-compose.yml")); err == nil {
+		services := string(compose)
+		cfg.UseRedis = strings.Contains(services, "\n  redis:")
+		switch {
+		case strings.Contains(services, "\n  postgres:"):
+			cfg.Database = DatabasePostgres
+		case strings.Contains(services, "\n  mysql:"):
+			cfg.Database = DatabaseMySQL
+		default:
+			cfg.Database = DatabaseSQLite
+		}
+	}
+
+	// HTTP 框架以 cmd/api/main.go 中导入的框架判断，未识别时保持默认的 Hertz
+	if main, err := os.ReadFile(filepath.Join(absDir, "cmd", "api", "main.go")); err == nil {
+		switch {
+		case strings.Contains(string(main), `"github.com/gin-gonic/gin"`):
+			cfg.Framework = FrameworkGin
+		case strings.Contains(string(main), `"github.com/go-chi/chi/v5"`):
+			cfg.Framework = FrameworkChi
+		}
+	}
+
+	return cfg, cfg.Validate()
+}
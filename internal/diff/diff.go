@@ -0,0 +1,141 @@
+package diff
+
+import "strings"
+
+// OpKind 编辑操作类型
+type OpKind int
+
+const (
+	Equal  OpKind = iota // 相同
+	Delete               // 仅在 A 中存在
+	Insert               // 仅在 B 中存在
+)
+
+// Op 编辑操作，A[A1:A2] 与 B[B1:B2] 对应
+type Op struct {
+	Kind   OpKind
+	A1, A2 int
+	B1, B2 int
+}
+
+// SplitLines 按行拆分文本，每行保留结尾的换行符
+func SplitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	lines := strings.SplitAfter(s, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// Lines 计算 a 到 b 的逐行编辑操作（Myers 差分算法），相邻的同类操作会被合并
+func Lines(a, b []string) []Op {
+	// 去掉公共前缀和后缀，缩小搜索范围
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	var ops []Op
+	add := func(kind OpKind, a1, a2, b1, b2 int) {
+		if a1 == a2 && b1 == b2 {
+			return
+		}
+		if n := len(ops); n > 0 && ops[n-1].Kind == kind && ops[n-1].A2 == a1 && ops[n-1].B2 == b1 {
+			ops[n-1].A2, ops[n-1].B2 = a2, b2
+			return
+		}
+		ops = append(ops, Op{Kind: kind, A1: a1, A2: a2, B1: b1, B2: b2})
+	}
+
+	add(Equal, 0, prefix, 0, prefix)
+	for _, op := range myers(a[prefix:len(a)-suffix], b[prefix:len(b)-suffix]) {
+		add(op.Kind, op.A1+prefix, op.A2+prefix, op.B1+prefix, op.B2+prefix)
+	}
+	add(Equal, len(a)-suffix, len(a), len(b)-suffix, len(b))
+
+	return ops
+}
+
+// myers Myers O(ND) 差分算法，返回逐行的编辑操作
+func myers(a, b []string) []Op {
+	n, m := len(a), len(b)
+	if n == 0 && m == 0 {
+		return nil
+	}
+	maxD := n + m
+	offset := maxD + 1
+	v := make([]int, 2*maxD+3)
+	// trace[d] 记录第 d 轮开始前 k ∈ [-d-1, d+1] 的 v 值，用于回溯
+	var trace [][]int
+
+	for d := 0; d <= maxD; d++ {
+		snapshot := make([]int, 2*d+3)
+		copy(snapshot, v[offset-d-1:offset+d+2])
+		trace = append(trace, snapshot)
+
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+				x = v[offset+k+1]
+			} else {
+				x = v[offset+k-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+			v[offset+k] = x
+			if x >= n && y >= m {
+				return backtrack(trace, n, m)
+			}
+		}
+	}
+	return nil
+}
+
+// backtrack 根据搜索轨迹还原编辑路径
+func backtrack(trace [][]int, n, m int) []Op {
+	var ops []Op
+	x, y := n, m
+	for d := len(trace) - 1; d >= 0; d-- {
+		v := func(k int) int { return trace[d][k+d+1] }
+		k := x - y
+
+		var prevK int
+		if k == -d || (k != d && v(k-1) < v(k+1)) {
+			prevK = k + 1
+		} else {
+			prevK = k - 1
+		}
+		prevX := v(prevK)
+		prevY := prevX - prevK
+
+		for x > prevX && y > prevY {
+			ops = append(ops, Op{Kind: Equal, A1: x - 1, A2: x, B1: y - 1, B2: y})
+			x--
+			y--
+		}
+		if d > 0 {
+			if x == prevX {
+				ops = append(ops, Op{Kind: Insert, A1: x, A2: x, B1: y - 1, B2: y})
+			} else {
+				ops = append(ops, Op{Kind: Delete, A1: x - 1, A2: x, B1: y, B2: y})
+			}
+		}
+		x, y = prevX, prevY
+	}
+
+	// 反转为正序
+	for i, j := 0, len(ops)-1; i < j; i, j = i+1, j-1 {
+		ops[i], ops[j] = ops[j], ops[i]
+	}
+	return ops
+}
@@ -0,0 +1,110 @@
+package diff
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DefaultContext 统一差异格式默认的上下文行数
+const DefaultContext = 3
+
+// Unified 生成统一差异格式（unified diff）文本，内容相同时返回空字符串
+func Unified(fromName, toName, a, b string, context int) string {
+	aLines, bLines := SplitLines(a), SplitLines(b)
+	ops := Lines(aLines, bLines)
+	hunks := groupHunks(ops, context)
+	if len(hunks) == 0 {
+		return ""
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "--- %s\n+++ %s\n", fromName, toName)
+	for _, hunk := range hunks {
+		first, last := hunk[0], hunk[len(hunk)-1]
+		fmt.Fprintf(&sb, "@@ -%s +%s @@\n", hunkRange(first.A1, last.A2), hunkRange(first.B1, last.B2))
+		for _, op := range hunk {
+			switch op.Kind {
+			case Equal:
+				writeLines(&sb, " ", aLines[op.A1:op.A2])
+			case Delete:
+				writeLines(&sb, "-", aLines[op.A1:op.A2])
+			case Insert:
+				writeLines(&sb, "+", bLines[op.B1:op.B2])
+			}
+		}
+	}
+	return sb.String()
+}
+
+// groupHunks 将编辑操作按上下文行数分组为差异块
+func groupHunks(ops []Op, context int) [][]Op {
+	var hunks [][]Op
+	var current []Op
+	for i, op := range ops {
+		if op.Kind != Equal {
+			current = append(current, op)
+			continue
+		}
+
+		size := op.A2 - op.A1
+		switch {
+		case len(current) == 0:
+			// 差异块开头：只保留末尾的上下文
+			if i == len(ops)-1 {
+				continue
+			}
+			start := max(op.A1, op.A2-context)
+			current = append(current, Op{Kind: Equal, A1: start, A2: op.A2, B1: op.B2 - (op.A2 - start), B2: op.B2})
+		case i == len(ops)-1 || size > 2*context:
+			// 差异块结尾：保留开头的上下文并结束当前块
+			end := min(op.A2, op.A1+context)
+			current = append(current, Op{Kind: Equal, A1: op.A1, A2: end, B1: op.B1, B2: op.B1 + (end - op.A1)})
+			hunks = append(hunks, current)
+			current = nil
+			if i < len(ops)-1 {
+				start := max(op.A1, op.A2-context)
+				current = append(current, Op{Kind: Equal, A1: start, A2: op.A2, B1: op.B2 - (op.A2 - start), B2: op.B2})
+			}
+		default:
+			current = append(current, op)
+		}
+	}
+	if hasChange(current) {
+		hunks = append(hunks, current)
+	}
+	return hunks
+}
+
+// hasChange 判断差异块中是否包含修改
+func hasChange(ops []Op) bool {
+	for _, op := range ops {
+		if op.Kind != Equal {
+			return true
+		}
+	}
+	return false
+}
+
+// hunkRange 差异块的行号范围（从 1 开始）
+func hunkRange(start, end int) string {
+	count := end - start
+	switch count {
+	case 0:
+		return fmt.Sprintf("%d,0", start)
+	case 1:
+		return fmt.Sprintf("%d", start+1)
+	default:
+		return fmt.Sprintf("%d,%d", start+1, count)
+	}
+}
+
+// writeLines 写入带前缀的行，缺少结尾换行符时补充标记
+func writeLines(sb *strings.Builder, prefix string, lines []string) {
+	for _, line := range lines {
+		sb.WriteString(prefix)
+		sb.WriteString(line)
+		if !strings.HasSuffix(line, "\n") {
+			sb.WriteString("\n\\ No newline at end of file\n")
+		}
+	}
+}
@@ -0,0 +1,114 @@
+package generator
+
+import (
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/tuza/scaffolding-code-generation/internal/config"
+	"github.com/tuza/scaffolding-code-generation/internal/manifest"
+	"github.com/tuza/scaffolding-code-generation/internal/spec"
+)
+
+// TestGeneratedProjectBuilds 生成项目并添加聚合后，对工作区中的全部模块执行 go build
+// 模板只渲染不编译，引用了不存在的方法等错误只能靠编译生成结果发现
+// 需要 go 命令和可用的模块缓存（或代理），-short 时跳过
+func TestGeneratedProjectBuilds(t *testing.T) {
+	if testing.Short() {
+		t.Skip("编译生成的项目耗时较长，-short 时跳过")
+	}
+	goBin, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("未找到 go 命令")
+	}
+
+	tests := []struct {
+		framework string
+		database  string
+		useRedis  bool
+	}{
+		{config.FrameworkHertz, config.DatabasePostgres, true},
+		{config.FrameworkGin, config.DatabaseMySQL, false},
+		{config.FrameworkChi, config.DatabaseSQLite, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.framework+"-"+tt.database, func(t *testing.T) {
+			cfg := config.NewProjectConfig()
+			cfg.ProjectName = "demo"
+			cfg.ModulePath = "example.com/demo"
+			cfg.OutputPath = t.TempDir()
+			cfg.Framework = tt.framework
+			cfg.Database = tt.database
+			cfg.UseRedis = tt.useRedis
+			projectDir := filepath.Join(cfg.OutputPath, cfg.ProjectName)
+
+			gen := NewGoGenerator(cfg)
+			gen.SetOutput(io.Discard)
+			if err := gen.Generate(); err != nil {
+				t.Fatalf("生成项目失败: %v", err)
+			}
+
+			fields, err := spec.ParseFields([]string{"name:string(64):required:unique", "price:decimal",
+				"status:enum(open,closed):required", "due:time", "type:string"})
+			if err != nil {
+				t.Fatal(err)
+			}
+			entity, err := spec.NewEntity("order_item", fields)
+			if err != nil {
+				t.Fatal(err)
+			}
+			m, err := manifest.Load(projectDir)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := NewAggregateGenerator(cfg, projectDir, m, entity, 12000).Generate(); err != nil {
+				t.Fatalf("添加聚合失败: %v", err)
+			}
+
+			dirs, err := WorkspaceModules(projectDir)
+			if err != nil {
+				t.Fatal(err)
+			}
+			// bom 只空导入第三方包，编译它只是在编译第三方模块
+			// cmd 下的 main 包只做类型检查：链接 hertz 依赖的 sonic 要求 Go 版本在它支持的范围内，与模板是否正确无关
+			var libs, mains []string
+			for _, dir := range dirs {
+				switch {
+				case dir == "bom":
+				case strings.HasPrefix(dir, "cmd/"):
+					mains = append(mains, "./"+dir+"/...")
+				default:
+					libs = append(libs, "./"+dir+"/...")
+				}
+			}
+			runGo(t, goBin, projectDir, append([]string{"build"}, libs...)...)
+			runGo(t, goBin, projectDir, append([]string{"vet"}, mains...)...)
+		})
+	}
+}
+
+// runGo 在生成的项目中执行 go 命令，失败时输出命令的输出
+func runGo(t *testing.T, goBin, dir string, args ...string) {
+	t.Helper()
+	cmd := exec.Command(goBin, args...)
+	cmd.Dir = dir
+	// 工作区模式只允许 -mod=readonly，覆盖环境中可能设置的其他值
+	cmd.Env = append(os.Environ(), "GOFLAGS=-mod=readonly", "GOWORK=")
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("go %s 失败: %v\n%s", args[0], err, filterNoPackages(string(out)))
+	}
+}
+
+// filterNoPackages 去掉 go build 对不含 Go 文件的模块（例如 user、api）输出的警告
+func filterNoPackages(out string) string {
+	var lines []string
+	for _, line := range strings.Split(out, "\n") {
+		if !strings.Contains(line, "matched no packages") {
+			lines = append(lines, line)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
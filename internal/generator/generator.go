@@ -1,10 +1,21 @@
 package generator
 
-import "github.com/tuza/scaffolding-code-generation/internal/config"
+import (
+	"context"
+	"io/fs"
+
+	"github.com/tuza/scaffolding-code-generation/internal/config"
+)
 
 // Generator 生成器接口
 type Generator interface {
 	Generate() error
+	// SetContext 设置生成的上下文，上下文被取消时停止生成
+	SetContext(ctx context.Context)
+	// SetTemplates 设置模板根目录，name 为记录到清单中的模板集名称
+	SetTemplates(root fs.FS, name string)
+	// SetVersions 设置覆盖内置版本目录的依赖版本（模块路径 -> 版本）
+	SetVersions(versions map[string]string) error
 }
 
 // NewGenerator 根据语言创建对应的生成器
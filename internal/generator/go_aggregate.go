@@ -0,0 +1,256 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/tuza/scaffolding-code-generation/internal/config"
+	"github.com/tuza/scaffolding-code-generation/internal/manifest"
+	"github.com/tuza/scaffolding-code-generation/internal/modgraph"
+	"github.com/tuza/scaffolding-code-generation/internal/output"
+	"github.com/tuza/scaffolding-code-generation/internal/spec"
+	"github.com/tuza/scaffolding-code-generation/internal/template"
+)
+
+// 生成的 cmd/api/main.go 中的插入标记，add aggregate 会在标记前插入代码
+const (
+	scaffoldImportsMarker    = "// +archi-gen:scaffold:imports"
+	scaffoldMigrationsMarker = "// +archi-gen:scaffold:migrations"
+	scaffoldRoutesMarker     = "// +archi-gen:scaffold:routes"
+)
+
+// AggregateGenerator 聚合生成器，在已有项目中新增一个限界上下文
+type AggregateGenerator struct {
+	*GoGenerator
+}
+
+// NewAggregateGenerator 创建聚合生成器
+// projectDir 为已有项目根目录，m 为项目清单，entity 为聚合根实体定义，errorCodeBase 为该聚合的错误码分段起始值
+func NewAggregateGenerator(cfg *config.ProjectConfig, projectDir string, m *manifest.Manifest, entity *spec.Entity, errorCodeBase int) *AggregateGenerator {
+	gen := NewGoGenerator(cfg)
+	// 写入先保存在内存中，全部步骤成功后才应用到项目，失败时项目保持不变
+	gen.fs = output.NewOverlayFS(projectDir)
+	gen.manifest = m
+	gen.tmplCtx = gen.tmplCtx.WithAggregate(entity, errorCodeBase)
+	return &AggregateGenerator{GoGenerator: gen}
+}
+
+// Generate 生成聚合模块并更新项目配置
+func (g *AggregateGenerator) Generate() error {
+	if g.fs.Exists(g.tmplCtx.Aggregate) {
+		return fmt.Errorf("聚合目录 '%s' 已存在", g.tmplCtx.Aggregate)
+	}
+
+	graph, err := g.aggregateGraph()
+	if err != nil {
+		return err
+	}
+	g.graph = graph
+
+	generated, err := g.moduleFiles(g.ModuleDirs())
+	if err != nil {
+		return err
+	}
+	steps, err := g.treeSteps(template.TreeAggregate, g.ModuleDirs(), generated)
+	if err != nil {
+		return err
+	}
+	steps = append(steps,
+		step{"更新 go.work", g.updateWorkspace},
+		step{"更新 BOM", g.updateBOM},
+		step{"更新 Makefile", g.updateMakefile},
+		step{"更新 Dockerfile", g.updateDockerfile},
+		step{"更新 api 聚合模块", g.updateAPIModule},
+		step{"更新 cmd/api 入口", g.updateCmd},
+		step{"更新 " + manifest.FileName, g.updateManifest},
+	)
+
+	return runSteps(g.ctx, g.out, g.fs, steps)
+}
+
+// ModuleDirs 返回聚合新增的 Go 模块目录（相对项目根目录）
+func (g *AggregateGenerator) ModuleDirs() []string {
+	return []string{
+		g.tmplCtx.Aggregate + "/domain",
+		g.tmplCtx.Aggregate + "/infrastructure",
+		g.tmplCtx.Aggregate,
+		"api/" + g.tmplCtx.AggregateKebab + "-api",
+	}
+}
+
+// aggregateGraph 返回加入了清单中已有聚合以及新聚合的项目模块依赖图
+func (g *AggregateGenerator) aggregateGraph() (*modgraph.Graph, error) {
+	catalog, err := modgraph.NewCatalog(g.manifest.Versions)
+	if err != nil {
+		return nil, err
+	}
+	graph := projectGraph(g.config, catalog)
+
+	for _, agg := range g.manifest.Aggregates {
+		if agg.Name == "user" || agg.Name == g.tmplCtx.Aggregate {
+			continue
+		}
+		entity, err := aggregateEntity(agg)
+		if err != nil {
+			return nil, err
+		}
+		if err := addAggregate(graph, agg.Name, entity, g.config.Framework); err != nil {
+			return nil, err
+		}
+	}
+	if err := addAggregate(graph, g.tmplCtx.Aggregate, g.tmplCtx.Entity, g.config.Framework); err != nil {
+		return nil, err
+	}
+	return graph, nil
+}
+
+// updateBOM 将新聚合用到的第三方模块加入 bom/go.mod，并重新生成 bom/bom.go 中的空导入
+func (g *AggregateGenerator) updateBOM() error {
+	changed, err := g.mergeGoMod("bom")
+	if err != nil || !changed {
+		return err
+	}
+
+	g.tmplCtx.BOMImports = g.graph.BOMPackages()
+	bom, err := g.tmplEngine.RenderFile(g.templates, template.TreeProject+"/bom/bom.go.tmpl", g.tmplCtx)
+	if err != nil {
+		return err
+	}
+	return g.editFile("bom/bom.go", func([]byte) ([]byte, error) {
+		return []byte(bom), nil
+	})
+}
+
+// updateManifest 在项目清单中记录新聚合及其模块
+func (g *AggregateGenerator) updateManifest() error {
+	g.manifest.AddModules(g.ModuleDirs()...)
+	g.manifest.AddAggregate(manifest.Aggregate{
+		Name:          g.tmplCtx.Aggregate,
+		ErrorCodeBase: g.tmplCtx.ErrorCodeBase,
+		Fields:        g.tmplCtx.Entity.FieldSpecs(),
+	})
+	return g.writeManifest()
+}
+
+// updateWorkspace 将新模块加入 go.work
+func (g *AggregateGenerator) updateWorkspace() error {
+	return g.editFile("go.work", g.graph.MergeGoWork)
+}
+
+// updateMakefile 在 tidy 目标中加入新模块
+func (g *AggregateGenerator) updateMakefile() error {
+	return g.editFile("Makefile", func(content []byte) ([]byte, error) {
+		var lines []string
+		for _, dir := range g.ModuleDirs() {
+			line := "\tcd " + dir + " && go mod tidy"
+			if !strings.Contains(string(content), line+"\n") {
+				lines = append(lines, line)
+			}
+		}
+		if len(lines) == 0 {
+			return content, nil
+		}
+		return insertLines(content, lines, true, "\tcd cmd/api && go mod tidy", "\tgo work sync")
+	})
+}
+
+// updateDockerfile 在 Dockerfile 中加入新模块的 go.mod 拷贝
+func (g *AggregateGenerator) updateDockerfile() error {
+	return g.editFile("Dockerfile", func(content []byte) ([]byte, error) {
+		var lines []string
+		for _, dir := range g.ModuleDirs() {
+			line := fmt.Sprintf("COPY %s/go.mod ./%s/", dir, dir)
+			if !strings.Contains(string(content), line+"\n") {
+				lines = append(lines, line)
+			}
+		}
+		if len(lines) == 0 {
+			return content, nil
+		}
+		return insertLines(content, lines, true, "COPY cmd/api/go.mod", "# Download dependencies")
+	})
+}
+
+// updateAPIModule 在 api 聚合模块中引用新的 API 模块
+func (g *AggregateGenerator) updateAPIModule() error {
+	_, err := g.mergeGoMod("api")
+	return err
+}
+
+// mergeGoMod 将依赖图中新增的 require / replace 合并到已有模块的 go.mod，返回是否有修改
+func (g *AggregateGenerator) mergeGoMod(dir string) (bool, error) {
+	relativePath := dir + "/go.mod"
+	content, err := g.fs.ReadFile(relativePath)
+	if err != nil {
+		return false, err
+	}
+	updated, changed, err := g.graph.MergeGoMod(dir, content)
+	if err != nil {
+		return false, fmt.Errorf("更新 %s 失败: %w", relativePath, err)
+	}
+	if !changed {
+		return false, nil
+	}
+	return true, g.writeFile(relativePath, string(updated))
+}
+
+// updateCmd 在 cmd/api 中引用新模块并注册迁移和路由
+func (g *AggregateGenerator) updateCmd() error {
+	modulePath := g.tmplCtx.ModulePath
+	apiDir := "api/" + g.tmplCtx.AggregateKebab + "-api"
+
+	if _, err := g.mergeGoMod("cmd/api"); err != nil {
+		return err
+	}
+
+	return g.editFile("cmd/api/main.go", func(content []byte) ([]byte, error) {
+		if strings.Contains(string(content), `"`+modulePath+"/"+apiDir+`/http"`) {
+			return content, nil
+		}
+
+		snippets := template.TreeSnippets + "/cmd-api/"
+		imports, err := g.tmplEngine.RenderFile(g.templates, snippets+"imports.go.tmpl", g.tmplCtx)
+		if err != nil {
+			return nil, err
+		}
+		migrations, err := g.tmplEngine.RenderFile(g.templates, snippets+"migrations.go.tmpl", g.tmplCtx)
+		if err != nil {
+			return nil, err
+		}
+		// 路由注册方式取决于 HTTP 框架
+		routes, err := g.tmplEngine.RenderFile(g.templates, template.FrameworkTree(g.tmplCtx.Framework, template.TreeSnippets)+"/cmd-api/routes.go.tmpl", g.tmplCtx)
+		if err != nil {
+			return nil, err
+		}
+
+		// 优先使用插入标记，旧版本生成的项目没有标记时退回到固定位置
+		content, err = insertLines(content, splitLines(imports), true, scaffoldImportsMarker)
+		if err != nil {
+			content, err = insertLines(content, splitLines(imports), false, "/user/infrastructure/repository\"")
+			if err != nil {
+				return nil, err
+			}
+		}
+		content, err = insertLines(content, splitLines(migrations), true, scaffoldMigrationsMarker, "\t// 初始化仓储")
+		if err != nil {
+			return nil, err
+		}
+		return insertLines(content, splitLines(routes), true, scaffoldRoutesMarker, "\t// 启动服务")
+	})
+}
+
+// editFile 读取项目中的文件，经 fn 修改后写回
+func (g *AggregateGenerator) editFile(relativePath string, fn func(content []byte) ([]byte, error)) error {
+	content, err := g.fs.ReadFile(relativePath)
+	if err != nil {
+		return err
+	}
+	updated, err := fn(content)
+	if err != nil {
+		return err
+	}
+	if updated, err = template.FormatOutput(relativePath, updated, g.tmplCtx.ModulePath); err != nil {
+		return fmt.Errorf("修改后的 %s 无效: %w", relativePath, err)
+	}
+	return g.writeFile(relativePath, string(updated))
+}
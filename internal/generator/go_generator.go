@@ -1,120 +1,151 @@
 package generator
 
 import (
+	"context"
 	"fmt"
+	"io"
+	"io/fs"
 	"os"
 	"path/filepath"
 
 	"github.com/tuza/scaffolding-code-generation/internal/config"
+	"github.com/tuza/scaffolding-code-generation/internal/manifest"
+	"github.com/tuza/scaffolding-code-generation/internal/modgraph"
+	"github.com/tuza/scaffolding-code-generation/internal/output"
 	"github.com/tuza/scaffolding-code-generation/internal/template"
 )
 
+// userErrorCodeBase user 聚合的错误码分段起始值
+const userErrorCodeBase = 11000
+
 // GoGenerator Go 项目生成器
 type GoGenerator struct {
 	config     *config.ProjectConfig
 	tmplEngine *template.Engine
 	tmplCtx    *template.Context
-	outputDir  string
+	templates  fs.FS           // 模板根目录，默认为内置模板
+	fs         output.FS       // 输出目标，根目录为项目根目录
+	graph      *modgraph.Graph // 模块依赖图，go.mod 和 go.work 由它生成
+	manifest   *manifest.Manifest
+	out        io.Writer       // 步骤进度输出
+	ctx        context.Context // 取消后在下一个步骤开始前停止生成
 }
 
 // NewGoGenerator 创建 Go 生成器
 func NewGoGenerator(cfg *config.ProjectConfig) *GoGenerator {
 	// 输出目录 = 输出路径 + 项目名称
 	outputDir := filepath.Join(cfg.OutputPath, cfg.ProjectName)
+
+	m := manifest.New(cfg)
+	m.AddAggregate(manifest.Aggregate{Name: "user", ErrorCodeBase: userErrorCodeBase})
+
 	return &GoGenerator{
 		config:     cfg,
 		tmplEngine: template.NewEngine(),
 		tmplCtx:    template.NewContext(cfg),
-		outputDir:  outputDir,
+		templates:  template.Builtin(),
+		fs:         output.NewStagingFS(outputDir),
+		manifest:   m,
+		out:        os.Stdout,
+		ctx:        context.Background(),
 	}
 }
 
-// Generate 生成项目
-func (g *GoGenerator) Generate() error {
-	steps := []struct {
-		name string
-		fn   func() error
-	}{
-		{"创建项目目录", g.createProjectDir},
-		{"生成 go.work", g.generateWorkspace},
-		{"生成 .gitignore", g.generateGitignore},
-		{"生成 Makefile", g.generateMakefile},
-		{"生成 BOM 模块", g.generateBOM},
-		{"生成 share 模块", g.generateShare},
-		{"生成 user/domain 模块", g.generateUserDomain},
-		{"生成 user/infrastructure 模块", g.generateUserInfra},
-		{"生成 user 聚合模块", g.generateUserModule},
-		{"生成 api/user-api 模块", g.generateUserAPI},
-		{"生成 api 聚合模块", g.generateAPIModule},
-		{"生成 cmd/api 入口", g.generateCmd},
-		{"生成 Dockerfile", g.generateDockerfile},
-		{"生成 docker-compose.yml", g.generateDockerCompose},
-		{"生成 .dockerignore", g.generateDockerignore},
-		{"生成 README.md", g.generateReadme},
-	}
+// SetFS 设置生成结果的输出目标
+// 默认先写入 输出路径/项目名称 旁边的临时目录，全部步骤成功后才重命名为该目录，失败或取消时删除临时目录
+func (g *GoGenerator) SetFS(fs output.FS) {
+	g.fs = fs
+}
 
-	for _, step := range steps {
-		fmt.Printf("   ✔ %s\n", step.name)
-		if err := step.fn(); err != nil {
-			return fmt.Errorf("%s 失败: %w", step.name, err)
-		}
-	}
+// SetTemplates 设置模板根目录（通常是叠加了用户模板目录的 template.Layers），
+// name 为记录到清单中的模板集名称
+func (g *GoGenerator) SetTemplates(root fs.FS, name string) {
+	g.templates = root
+	g.manifest.Templates = name
+}
 
+// SetVersions 设置覆盖内置版本目录的依赖版本（模块路径 -> 版本），并记录到清单中
+func (g *GoGenerator) SetVersions(versions map[string]string) error {
+	if _, err := modgraph.NewCatalog(versions); err != nil {
+		return err
+	}
+	g.manifest.Versions = versions
 	return nil
 }
 
-// createProjectDir 创建项目目录结构
-func (g *GoGenerator) createProjectDir() error {
-	dirs := []string{
-		"bom",
-		"share/errors",
-		"share/types",
-		"user/domain/entity",
-		"user/domain/repository",
-		"user/domain/service",
-		"user/domain/valueobject",
-		"user/domain/event",
-		"user/domain/enum",
-		"user/domain/errors",
-		"user/infrastructure/entity",
-		"user/infrastructure/converter",
-		"user/infrastructure/repository",
-		"api/user-api/dto/vo",
-		"api/user-api/dto/request",
-		"api/user-api/converter",
-		"api/user-api/service",
-		"api/user-api/http",
-		"cmd/api",
+// SetOutput 设置步骤进度的输出位置，传入 io.Discard 可关闭输出
+func (g *GoGenerator) SetOutput(w io.Writer) {
+	g.out = w
+}
+
+// SetContext 设置生成的上下文，上下文被取消（例如 Ctrl-C）时停止生成并回滚
+func (g *GoGenerator) SetContext(ctx context.Context) {
+	g.ctx = ctx
+}
+
+// Manifest 返回生成过程中记录的项目清单
+func (g *GoGenerator) Manifest() *manifest.Manifest {
+	return g.manifest
+}
+
+// Generate 遍历项目模板树生成项目，各模块的 go.mod 和 go.work 由模块依赖图生成
+func (g *GoGenerator) Generate() error {
+	catalog, err := modgraph.NewCatalog(g.manifest.Versions)
+	if err != nil {
+		return err
+	}
+	g.graph = projectGraph(g.config, catalog)
+	dirs, err := g.graph.Dirs()
+	if err != nil {
+		return err
+	}
+	g.tmplCtx.Modules = dirs
+	g.tmplCtx.BOMImports = g.graph.BOMPackages()
+	g.manifest.AddModules(dirs...)
+
+	generated, err := g.moduleFiles(dirs)
+	if err != nil {
+		return err
+	}
+	work, err := g.graph.GoWork()
+	if err != nil {
+		return err
+	}
+	generated = append(generated, generatedFile{Path: "go.work", Content: work})
+
+	steps, err := g.treeSteps(template.TreeProject, dirs, generated)
+	if err != nil {
+		return err
 	}
+	steps = append(steps, step{"生成 " + manifest.FileName, g.writeManifest})
+
+	return runSteps(g.ctx, g.out, g.fs, steps)
+}
 
+// moduleFiles 由模块依赖图生成 dirs 中各模块的 go.mod
+func (g *GoGenerator) moduleFiles(dirs []string) ([]generatedFile, error) {
+	files := make([]generatedFile, 0, len(dirs))
 	for _, dir := range dirs {
-		fullPath := filepath.Join(g.outputDir, dir)
-		if err := os.MkdirAll(fullPath, 0755); err != nil {
-			return err
+		content, err := g.graph.GoMod(dir)
+		if err != nil {
+			return nil, fmt.Errorf("生成 %s/go.mod 失败: %w", dir, err)
 		}
+		files = append(files, generatedFile{Path: dir + "/go.mod", Content: content})
 	}
-
-	return nil
+	return files, nil
 }
 
 // writeFile 写入文件
 func (g *GoGenerator) writeFile(relativePath, content string) error {
-	fullPath := filepath.Join(g.outputDir, relativePath)
-
-	// 确保目录存在
-	dir := filepath.Dir(fullPath)
-	if err := os.MkdirAll(dir, 0755); err != nil {
+	if err := g.fs.WriteFile(relativePath, []byte(content)); err != nil {
 		return err
 	}
-
-	return os.WriteFile(fullPath, []byte(content), 0644)
+	g.manifest.RecordFile(relativePath, []byte(content))
+	return nil
 }
 
-// renderAndWrite 渲染模板并写入文件
-func (g *GoGenerator) renderAndWrite(tmplContent, relativePath string) error {
-	rendered, err := g.tmplEngine.Render(tmplContent, g.tmplCtx)
-	if err != nil {
-		return err
-	}
-	return g.writeFile(relativePath, rendered)
+// writeManifest 写入项目清单
+func (g *GoGenerator) writeManifest() error {
+	g.manifest.Touch()
+	return g.manifest.Export(g.fs.WriteFile)
 }
@@ -0,0 +1,236 @@
+package generator
+
+import (
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/tuza/scaffolding-code-generation/internal/config"
+	"github.com/tuza/scaffolding-code-generation/internal/spec"
+)
+
+// TestGeneratedUpdateWithIfMatch 在生成的 SQLite 项目中调用聚合的 HTTP 处理器：
+// 更新请求的请求体不带 version、只通过 If-Match 请求头传入版本号时应更新成功，过期的版本号返回 409
+func TestGeneratedUpdateWithIfMatch(t *testing.T) {
+	for _, framework := range []string{config.FrameworkHertz, config.FrameworkGin, config.FrameworkChi} {
+		t.Run(framework, func(t *testing.T) {
+			src := strings.NewReplacer(
+				"$IMPORTS", ifMatchTestImports[framework],
+				"$SERVE", ifMatchTestServe[framework],
+			).Replace(ifMatchTestSource)
+			runGeneratedTest(t, framework, "if_match_test.go", src)
+		})
+	}
+}
+
+// runGeneratedTest 生成带 order_item 聚合的 SQLite 项目，把测试源码 src 写入 cmd/api 并执行 go test
+// 测试放在 cmd/api 中：只有它同时依赖处理器、仓储实现和数据库驱动
+// 需要 go 命令和可用的模块缓存（或代理），-short 时跳过
+func runGeneratedTest(t *testing.T, framework, name, src string) {
+	t.Helper()
+	if testing.Short() {
+		t.Skip("编译生成的项目耗时较长，-short 时跳过")
+	}
+	goBin, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("未找到 go 命令")
+	}
+
+	cfg := config.NewProjectConfig()
+	cfg.ProjectName = "demo"
+	cfg.ModulePath = "example.com/demo"
+	cfg.OutputPath = t.TempDir()
+	cfg.Framework = framework
+	cfg.Database = config.DatabaseSQLite
+	cfg.UseRedis = false
+	projectDir := filepath.Join(cfg.OutputPath, cfg.ProjectName)
+
+	gen := NewGoGenerator(cfg)
+	gen.SetOutput(io.Discard)
+	if err := gen.Generate(); err != nil {
+		t.Fatalf("生成项目失败: %v", err)
+	}
+	fields, err := spec.ParseFields([]string{"name:string(64):required"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	entity, err := spec.NewEntity("order_item", fields)
+	if err != nil {
+		t.Fatal(err)
+	}
+	agg := NewAggregateGenerator(cfg, projectDir, gen.Manifest(), entity, 12000)
+	agg.SetOutput(io.Discard)
+	if err := agg.Generate(); err != nil {
+		t.Fatalf("添加聚合失败: %v", err)
+	}
+
+	if err := os.WriteFile(filepath.Join(projectDir, "cmd", "api", name), []byte(src), 0644); err != nil {
+		t.Fatal(err)
+	}
+	cmd := exec.Command(goBin, "test", "-count=1", "./cmd/api/")
+	cmd.Dir = projectDir
+	cmd.Env = append(os.Environ(), "GOFLAGS=-mod=readonly", "GOWORK=")
+	out, err := cmd.CombinedOutput()
+	if err != nil && framework == config.FrameworkHertz && strings.Contains(string(out), "github.com/bytedance/sonic") {
+		// 与 TestGeneratedProjectBuilds 相同：sonic 只能在它支持的 Go 版本下链接，此时只对测试做类型检查
+		runGo(t, goBin, projectDir, "vet", "./cmd/api/")
+		t.Skipf("当前 Go 版本无法链接 hertz 依赖的 sonic:\n%s", lastLines(string(out), 5))
+	}
+	if err != nil {
+		t.Fatalf("go test 失败: %v\n%s", err, out)
+	}
+}
+
+// lastLines 返回 s 的最后 n 行
+func lastLines(s string, n int) string {
+	lines := strings.Split(strings.TrimRight(s, "\n"), "\n")
+	if len(lines) > n {
+		lines = lines[len(lines)-n:]
+	}
+	return strings.Join(lines, "\n")
+}
+
+// ifMatchTestSource 写入生成项目的测试，$IMPORTS 和 $SERVE 按 HTTP 框架替换
+// serve 注册 OrderItem 的路由，返回发送请求并返回状态码、ETag 和响应体的函数
+const ifMatchTestSource = `package main
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	orderItemHTTP "example.com/demo/api/order-item-api/http"
+	orderItemInfraEntity "example.com/demo/order_item/infrastructure/entity"
+	orderItemInfraRepo "example.com/demo/order_item/infrastructure/repository"
+	"example.com/demo/share/event"
+	"example.com/demo/share/outbox"
+	basegorm "example.com/demo/share/repository/gorm"
+$IMPORTS
+)
+
+type requestFunc func(method, path, body, ifMatch string) (code int, etag string, respBody []byte)
+
+func TestUpdateWithIfMatchOnly(t *testing.T) {
+	db, err := basegorm.NewDatabaseFactory(&basegorm.DatabaseConfig{
+		Type:     basegorm.SQLite,
+		Database: filepath.Join(t.TempDir(), "test.db"),
+	}).Create()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := db.AutoMigrate(&outbox.MessagePO{}, &orderItemInfraEntity.OrderItemPO{}); err != nil {
+		t.Fatal(err)
+	}
+	handler := orderItemHTTP.NewOrderItemHandler(orderItemInfraRepo.NewOrderItemRepositoryImpl(db),
+		basegorm.NewUnitOfWork(db), event.NewBus())
+	do := serve(handler)
+
+	code, _, body := do("POST", "/order-items", "{\"name\":\"first\"}", "")
+	if code != 200 {
+		t.Fatalf("创建: %d %s", code, body)
+	}
+	// encoding/json 匹配字段名时不区分大小写
+	var created struct {
+		Data struct {
+			ID      string
+			Version int
+		}
+	}
+	if err := json.Unmarshal(body, &created); err != nil {
+		t.Fatal(err)
+	}
+	path := "/order-items/" + created.Data.ID
+	ifMatch := strconv.Quote(strconv.Itoa(created.Data.Version))
+
+	// 请求体不带 version，只通过 If-Match 传入版本号
+	code, etag, body := do("PUT", path, "{\"name\":\"second\"}", ifMatch)
+	if code != 200 {
+		t.Fatalf("只带 If-Match 的更新: %d %s", code, body)
+	}
+	if want := strconv.Quote(strconv.Itoa(created.Data.Version + 1)); etag != want {
+		t.Errorf("ETag = %s, want %s", etag, want)
+	}
+
+	// 版本号已过期
+	if code, _, body := do("PUT", path, "{\"name\":\"third\"}", ifMatch); code != 409 {
+		t.Errorf("过期的 If-Match: %d %s, want 409", code, body)
+	}
+}
+$SERVE`
+
+// ifMatchTestImports 各 HTTP 框架的测试额外导入的包
+var ifMatchTestImports = map[string]string{
+	config.FrameworkHertz: `
+	"bytes"
+
+	"github.com/cloudwego/hertz/pkg/app/server"
+	"github.com/cloudwego/hertz/pkg/common/ut"`,
+	config.FrameworkGin: `
+	"net/http/httptest"
+	"strings"
+
+	"github.com/gin-gonic/gin"`,
+	config.FrameworkChi: `
+	"net/http/httptest"
+	"strings"
+
+	"github.com/go-chi/chi/v5"`,
+}
+
+// ifMatchTestServe 各 HTTP 框架注册路由并发送请求的 serve 函数
+var ifMatchTestServe = map[string]string{
+	config.FrameworkHertz: `
+func serve(handler *orderItemHTTP.OrderItemHandler) requestFunc {
+	h := server.New()
+	h.POST("/order-items", handler.CreateOrderItem)
+	h.PUT("/order-items/:id", handler.UpdateOrderItem)
+	return func(method, path, body, ifMatch string) (int, string, []byte) {
+		headers := []ut.Header{{Key: "Content-Type", Value: "application/json"}}
+		if ifMatch != "" {
+			headers = append(headers, ut.Header{Key: "If-Match", Value: ifMatch})
+		}
+		resp := ut.PerformRequest(h.Engine, method, path, &ut.Body{Body: bytes.NewBufferString(body), Len: len(body)}, headers...).Result()
+		return resp.StatusCode(), string(resp.Header.Peek("ETag")), resp.Body()
+	}
+}
+`,
+	config.FrameworkGin: `
+func serve(handler *orderItemHTTP.OrderItemHandler) requestFunc {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.POST("/order-items", handler.CreateOrderItem)
+	r.PUT("/order-items/:id", handler.UpdateOrderItem)
+	return func(method, path, body, ifMatch string) (int, string, []byte) {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		if ifMatch != "" {
+			req.Header.Set("If-Match", ifMatch)
+		}
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w.Code, w.Header().Get("ETag"), w.Body.Bytes()
+	}
+}
+`,
+	config.FrameworkChi: `
+func serve(handler *orderItemHTTP.OrderItemHandler) requestFunc {
+	r := chi.NewRouter()
+	r.Post("/order-items", handler.CreateOrderItem)
+	r.Put("/order-items/{id}", handler.UpdateOrderItem)
+	return func(method, path, body, ifMatch string) (int, string, []byte) {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		if ifMatch != "" {
+			req.Header.Set("If-Match", ifMatch)
+		}
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w.Code, w.Header().Get("ETag"), w.Body.Bytes()
+	}
+}
+`,
+}
@@ -0,0 +1,141 @@
+package generator
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/tuza/scaffolding-code-generation/internal/config"
+	"github.com/tuza/scaffolding-code-generation/internal/manifest"
+	"github.com/tuza/scaffolding-code-generation/internal/modgraph"
+	"github.com/tuza/scaffolding-code-generation/internal/spec"
+	"github.com/tuza/scaffolding-code-generation/internal/template"
+)
+
+// projectGraph 返回 init 生成的模块及其依赖：go.mod、go.work、Makefile 和 Dockerfile 中的模块列表都由它计算
+// 第三方模块的版本取自 catalog，bom 模块的 go.mod 由全部模块用到的第三方模块派生
+func projectGraph(cfg *config.ProjectConfig, catalog *modgraph.Catalog) *modgraph.Graph {
+	bomDeps := []string{
+		"github.com/bytedance/sonic",
+		"github.com/cloudwego/kitex",
+		"github.com/go-playground/validator/v10",
+		"github.com/google/uuid",
+		"github.com/spf13/viper",
+		"gorm.io/gorm",
+	}
+	bomDeps = append(bomDeps, frameworkModule(cfg.Framework))
+	shareDeps := append(shareHTTPDeps(cfg.Framework),
+		"github.com/bytedance/sonic",
+		"github.com/google/uuid",
+		"github.com/spf13/viper",
+		"gorm.io/driver/"+cfg.Database,
+		"gorm.io/gorm",
+	)
+	if cfg.UseRedis {
+		// share/cache 封装 Redis 客户端
+		bomDeps = append(bomDeps, "github.com/redis/go-redis/v9")
+		shareDeps = append(shareDeps, "github.com/redis/go-redis/v9")
+	}
+
+	g := modgraph.New(cfg.ModulePath, catalog)
+	g.SetBOM("bom")
+	mustAdd(g,
+		modgraph.Module{Dir: "bom", Deps: bomDeps},
+		modgraph.Module{
+			Dir:      "share",
+			Requires: []string{"bom"},
+			Deps:     shareDeps,
+		},
+	)
+	userModules := aggregateModules("user", "user", nil, cfg.Framework)
+	// user 聚合的 Password 值对象使用 bcrypt 计算密码哈希
+	userModules[0].Deps = append(userModules[0].Deps, "golang.org/x/crypto")
+	mustAdd(g, userModules...)
+	mustAdd(g,
+		modgraph.Module{Dir: "api", Requires: []string{"api/user-api"}},
+		modgraph.Module{
+			Dir:      "cmd/api",
+			Requires: []string{"bom", "share", "user/domain", "user/infrastructure", "api/user-api"},
+			Deps:     []string{frameworkModule(cfg.Framework)},
+		},
+		// 发件箱 relay 只依赖 share 中的配置、日志和 outbox
+		modgraph.Module{Dir: "cmd/outbox-relay", Requires: []string{"bom", "share"}},
+	)
+	return g
+}
+
+// frameworkModule 返回 HTTP 框架所在的第三方模块
+func frameworkModule(framework string) string {
+	switch framework {
+	case config.FrameworkGin:
+		return "github.com/gin-gonic/gin"
+	case config.FrameworkChi:
+		return "github.com/go-chi/chi/v5"
+	default:
+		return "github.com/cloudwego/hertz"
+	}
+}
+
+// shareHTTPDeps 返回 share 模块中 HTTP 相关代码用到的第三方模块
+// chi 的 share/middleware 使用 chi/middleware 记录响应状态，share/render 使用 validator 校验请求
+func shareHTTPDeps(framework string) []string {
+	if framework == config.FrameworkChi {
+		return []string{frameworkModule(framework), "github.com/go-playground/validator/v10"}
+	}
+	return []string{frameworkModule(framework)}
+}
+
+// aggregateModules 返回一个聚合的四个模块：domain、infrastructure、聚合目录本身和 api/<kebab>-api
+// entity 为 nil 时表示只使用默认字段，framework 为项目使用的 HTTP 框架
+func aggregateModules(aggregate, kebab string, entity *spec.Entity, framework string) []modgraph.Module {
+	deps := []string{"github.com/google/uuid"}
+	if entity != nil && entity.UsesType(spec.TypeDecimal) {
+		deps = append(deps, "github.com/shopspring/decimal")
+	}
+	deps = slices.Clip(deps)
+	domain := aggregate + "/domain"
+	infra := aggregate + "/infrastructure"
+
+	return []modgraph.Module{
+		{Dir: domain, Requires: []string{"bom", "share"}, Deps: deps},
+		{Dir: infra, Requires: []string{"bom", "share", domain}, Deps: append(deps, "gorm.io/gorm")},
+		{Dir: aggregate, Requires: []string{domain, infra}},
+		{
+			Dir:      "api/" + kebab + "-api",
+			Requires: []string{"bom", "share", domain},
+			Deps:     append(deps, frameworkModule(framework)),
+		},
+	}
+}
+
+// mustAdd 加入内置的模块声明，声明重复属于程序错误
+func mustAdd(g *modgraph.Graph, modules ...modgraph.Module) {
+	if err := g.Add(modules...); err != nil {
+		panic(err)
+	}
+}
+
+// addAggregate 在依赖图中加入聚合的模块，并让 api 和 cmd/api 依赖它们
+func addAggregate(g *modgraph.Graph, aggregate string, entity *spec.Entity, framework string) error {
+	kebab := template.ToKebabCase(aggregate)
+	if err := g.Add(aggregateModules(aggregate, kebab, entity, framework)...); err != nil {
+		return err
+	}
+
+	apiDir := "api/" + kebab + "-api"
+	if err := g.Require("api", apiDir); err != nil {
+		return err
+	}
+	return g.Require("cmd/api", aggregate+"/domain", aggregate+"/infrastructure", apiDir)
+}
+
+// aggregateEntity 根据清单中记录的字段定义还原聚合根实体，没有记录字段时使用默认字段
+func aggregateEntity(agg manifest.Aggregate) (*spec.Entity, error) {
+	if len(agg.Fields) == 0 {
+		return spec.DefaultEntity(agg.Name), nil
+	}
+	fields, err := spec.ParseFields(agg.Fields)
+	if err != nil {
+		return nil, fmt.Errorf("聚合 %s 的字段定义无效: %w", agg.Name, err)
+	}
+	return spec.NewEntity(agg.Name, fields)
+}
@@ -0,0 +1,85 @@
+package generator
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/mod/modfile"
+)
+
+// insertLines 在第一个包含锚点的行之前（或之后）插入若干行
+// anchors 按顺序尝试，用于兼容不同版本生成的文件
+func insertLines(content []byte, lines []string, before bool, anchors ...string) ([]byte, error) {
+	existing := strings.SplitAfter(string(content), "\n")
+	for _, anchor := range anchors {
+		for i, line := range existing {
+			if !strings.Contains(line, anchor) {
+				continue
+			}
+			pos := i
+			if !before {
+				pos = i + 1
+			}
+
+			var b strings.Builder
+			for _, l := range existing[:pos] {
+				b.WriteString(l)
+			}
+			for _, l := range lines {
+				b.WriteString(l)
+				b.WriteString("\n")
+			}
+			for _, l := range existing[pos:] {
+				b.WriteString(l)
+			}
+			return []byte(b.String()), nil
+		}
+	}
+	return nil, fmt.Errorf("未找到插入位置: %s", strings.Join(anchors, " / "))
+}
+
+// splitLines 将文本按行拆分（去掉末尾换行）
+func splitLines(s string) []string {
+	return strings.Split(strings.TrimSuffix(s, "\n"), "\n")
+}
+
+// NextErrorCodeBase 根据项目中已有的聚合数量计算下一个错误码分段
+// user 聚合占用 11xxx，之后的聚合依次为 12xxx、13xxx...
+func NextErrorCodeBase(projectDir string) (int, error) {
+	content, err := os.ReadFile(filepath.Join(projectDir, "go.work"))
+	if err != nil {
+		return 0, err
+	}
+	f, err := modfile.ParseWork("go.work", content, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	aggregates := 0
+	for _, use := range f.Use {
+		if strings.HasSuffix(use.Path, "/domain") {
+			aggregates++
+		}
+	}
+	return (11 + aggregates) * 1000, nil
+}
+
+// WorkspaceModules 读取 go.work 中的模块目录（相对项目根目录）
+func WorkspaceModules(projectDir string) ([]string, error) {
+	content, err := os.ReadFile(filepath.Join(projectDir, "go.work"))
+	if err != nil {
+		return nil, err
+	}
+	f, err := modfile.ParseWork("go.work", content, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	dirs := make([]string, 0, len(f.Use))
+	for _, use := range f.Use {
+		dirs = append(dirs, strings.TrimPrefix(filepath.ToSlash(use.Path), "./"))
+	}
+	return dirs, nil
+}
@@ -0,0 +1,52 @@
+package generator
+
+import (
+	"fmt"
+	"io"
+	iofs "io/fs"
+
+	"github.com/tuza/scaffolding-code-generation/internal/config"
+	"github.com/tuza/scaffolding-code-generation/internal/manifest"
+	"github.com/tuza/scaffolding-code-generation/internal/output"
+)
+
+// RenderProject 按项目配置和清单中记录的聚合，用当前版本的模板重新生成完整项目到 fs
+// templates 为模板根目录，nil 表示使用内置模板
+// 返回本次生成记录的新清单（包含全部文件的校验和与快照，尚未保存到项目中）
+func RenderProject(cfg *config.ProjectConfig, m *manifest.Manifest, fs output.FS, templates iofs.FS) (*manifest.Manifest, error) {
+	gen := NewGoGenerator(cfg)
+	gen.SetFS(fs)
+	if templates != nil {
+		gen.SetTemplates(templates, m.Templates)
+	}
+	if err := gen.SetVersions(m.Versions); err != nil {
+		return nil, err
+	}
+	gen.SetOutput(io.Discard)
+	if err := gen.Generate(); err != nil {
+		return nil, err
+	}
+
+	for _, agg := range m.Aggregates {
+		if agg.Name == "user" {
+			continue
+		}
+
+		entity, err := aggregateEntity(agg)
+		if err != nil {
+			return nil, err
+		}
+
+		aggGen := NewAggregateGenerator(cfg, "", gen.Manifest(), entity, agg.ErrorCodeBase)
+		aggGen.SetFS(fs)
+		if templates != nil {
+			aggGen.SetTemplates(templates, m.Templates)
+		}
+		aggGen.SetOutput(io.Discard)
+		if err := aggGen.Generate(); err != nil {
+			return nil, fmt.Errorf("生成聚合 %s 失败: %w", agg.Name, err)
+		}
+	}
+
+	return gen.Manifest(), nil
+}
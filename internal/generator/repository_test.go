@@ -0,0 +1,72 @@
+package generator
+
+import (
+	"testing"
+
+	"github.com/tuza/scaffolding-code-generation/internal/config"
+)
+
+// TestGeneratedRestore 在生成的 SQLite 项目中调用 GormRepository.Restore：
+// 只有已逻辑删除的记录可以恢复，记录不存在或未被删除时返回 NotFound 错误
+func TestGeneratedRestore(t *testing.T) {
+	// Restore 与 HTTP 框架无关，使用可以在任意 Go 版本下链接的 chi
+	runGeneratedTest(t, config.FrameworkChi, "restore_test.go", restoreTestSource)
+}
+
+// restoreTestSource 写入生成项目的测试
+const restoreTestSource = `package main
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/uuid"
+
+	orderItemInfraEntity "example.com/demo/order_item/infrastructure/entity"
+	baseErrors "example.com/demo/share/errors"
+	basegorm "example.com/demo/share/repository/gorm"
+)
+
+func TestRestore(t *testing.T) {
+	db, err := basegorm.NewDatabaseFactory(&basegorm.DatabaseConfig{
+		Type:     basegorm.SQLite,
+		Database: filepath.Join(t.TempDir(), "test.db"),
+	}).Create()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := db.AutoMigrate(&orderItemInfraEntity.OrderItemPO{}); err != nil {
+		t.Fatal(err)
+	}
+	repo := basegorm.NewGormRepository[orderItemInfraEntity.OrderItemPO, uuid.UUID](db)
+	ctx := context.Background()
+
+	po := &orderItemInfraEntity.OrderItemPO{ID: uuid.New(), Name: "first"}
+	if err := repo.Create(ctx, po); err != nil {
+		t.Fatal(err)
+	}
+
+	assertNotFound := func(name string, err error) {
+		t.Helper()
+		var appErr *baseErrors.AppError
+		if !errors.As(err, &appErr) || appErr.Code != baseErrors.NotFound {
+			t.Errorf("%s: Restore() error = %v, want NotFound", name, err)
+		}
+	}
+	assertNotFound("未被删除", repo.Restore(ctx, po.ID))
+	assertNotFound("不存在", repo.Restore(ctx, uuid.New()))
+
+	if err := repo.Delete(ctx, po.ID); err != nil {
+		t.Fatal(err)
+	}
+	if err := repo.Restore(ctx, po.ID); err != nil {
+		t.Fatalf("Restore() error = %v", err)
+	}
+	if got, err := repo.GetByID(ctx, po.ID); err != nil || got == nil {
+		t.Fatalf("恢复后 GetByID() = %v, %v", got, err)
+	}
+	assertNotFound("已恢复", repo.Restore(ctx, po.ID))
+}
+`
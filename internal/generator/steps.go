@@ -0,0 +1,45 @@
+package generator
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/tuza/scaffolding-code-generation/internal/output"
+)
+
+// step 生成步骤
+type step struct {
+	name string
+	fn   func() error
+}
+
+// runSteps 依次执行生成步骤，每个步骤成功后才输出 ✔，失败时输出 ✘ 并返回错误
+// ctx 被取消时在下一个步骤开始前停止；fs 支持事务时全部成功才提交，否则回滚
+func runSteps(ctx context.Context, out io.Writer, fs output.FS, steps []step) (err error) {
+	if tx, ok := fs.(output.Transaction); ok {
+		defer func() {
+			if err == nil {
+				if err = tx.Commit(); err == nil {
+					return
+				}
+				err = fmt.Errorf("写入项目目录失败: %w", err)
+			}
+			if rbErr := tx.Rollback(); rbErr != nil {
+				err = fmt.Errorf("%w（清理临时文件失败: %v）", err, rbErr)
+			}
+		}()
+	}
+
+	for _, s := range steps {
+		if err := ctx.Err(); err != nil {
+			return fmt.Errorf("已取消: %w", err)
+		}
+		if err := s.fn(); err != nil {
+			fmt.Fprintf(out, "   ✘ %s\n", s.name)
+			return fmt.Errorf("%s 失败: %w", s.name, err)
+		}
+		fmt.Fprintf(out, "   ✔ %s\n", s.name)
+	}
+	return nil
+}
@@ -0,0 +1,127 @@
+package generator
+
+import (
+	"fmt"
+	"io/fs"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/tuza/scaffolding-code-generation/internal/template"
+)
+
+// generatedFile 不经过模板、由生成器直接计算内容的文件（例如由模块依赖图生成的 go.mod）
+type generatedFile struct {
+	Path    string
+	Content []byte
+}
+
+// treeEntry 生成步骤中的一个文件，来自模板或 generatedFile
+type treeEntry struct {
+	path     string
+	template string // 模板路径，为空时使用 content
+	content  []byte
+}
+
+// treeSteps 遍历模板树 tree（例如 template.TreeProject）及其 HTTP 框架专属部分，连同 generated 中的文件按所在的 Go 模块分组为生成步骤
+// modules 为模块目录（相对项目根目录），不属于任何模块的文件各自作为一个步骤
+// 渲染结果为空白的模板表示该文件不需要生成，其余文件经 template.FormatOutput 规范化后写入
+func (g *GoGenerator) treeSteps(tree string, modules []string, generated []generatedFile) ([]step, error) {
+	files, err := g.walkTree(tree)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]treeEntry, 0, len(files)+len(generated))
+	for _, f := range generated {
+		entries = append(entries, treeEntry{path: f.Path, content: f.Content})
+	}
+	for _, f := range files {
+		if slices.ContainsFunc(generated, func(gf generatedFile) bool { return gf.Path == f.Path }) {
+			return nil, fmt.Errorf("模板 %s 与生成的 %s 冲突：%s 由模块依赖图生成，请删除该模板", f.Template, f.Path, path.Base(f.Path))
+		}
+		entries = append(entries, treeEntry{path: f.Path, template: f.Template})
+	}
+	// 与遍历模板树的顺序一致：逐级按名称排序
+	slices.SortStableFunc(entries, func(a, b treeEntry) int {
+		return slices.Compare(strings.Split(a.path, "/"), strings.Split(b.path, "/"))
+	})
+
+	var steps []step
+	groups := make(map[string][]treeEntry)
+	for _, e := range entries {
+		name := "生成 " + e.path
+		if module := moduleOf(e.path, modules); module != "" {
+			name = "生成 " + module + " 模块"
+		}
+		if _, ok := groups[name]; !ok {
+			steps = append(steps, step{name: name})
+		}
+		groups[name] = append(groups[name], e)
+	}
+
+	for i := range steps {
+		group := groups[steps[i].name]
+		steps[i].fn = func() error {
+			for _, e := range group {
+				if e.template == "" {
+					if err := g.writeFile(e.path, string(e.content)); err != nil {
+						return err
+					}
+					continue
+				}
+				if err := g.renderEntry(e); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+	}
+	return steps, nil
+}
+
+// walkTree 遍历模板树 tree 及其 HTTP 框架专属部分，两者输出同一文件时报错
+func (g *GoGenerator) walkTree(tree string) ([]template.TreeFile, error) {
+	files, err := g.tmplEngine.Walk(g.templates, tree, g.tmplCtx)
+	if err != nil {
+		return nil, err
+	}
+	frameworkFiles, err := g.tmplEngine.Walk(g.templates, template.FrameworkTree(g.tmplCtx.Framework, tree), g.tmplCtx)
+	if err != nil {
+		return nil, err
+	}
+	for _, f := range frameworkFiles {
+		if i := slices.IndexFunc(files, func(tf template.TreeFile) bool { return tf.Path == f.Path }); i >= 0 {
+			return nil, fmt.Errorf("模板 %s 与 %s 冲突：两者都输出 %s", f.Template, files[i].Template, f.Path)
+		}
+	}
+	return append(files, frameworkFiles...), nil
+}
+
+// renderEntry 渲染模板文件，规范化后写入
+func (g *GoGenerator) renderEntry(e treeEntry) error {
+	content, err := g.tmplEngine.RenderFile(g.templates, e.template, g.tmplCtx)
+	if err != nil {
+		return fmt.Errorf("渲染模板 %s 失败: %w", e.template, err)
+	}
+	if strings.TrimSpace(content) == "" {
+		return nil
+	}
+	formatted, err := template.FormatOutput(e.path, []byte(content), g.tmplCtx.ModulePath)
+	if err != nil {
+		tmplContent, _ := fs.ReadFile(g.templates, e.template)
+		return template.NewSourceError(e.template, tmplContent, []byte(content), e.path, err)
+	}
+	return g.writeFile(e.path, string(formatted))
+}
+
+// moduleOf 返回文件所在的模块目录（最长匹配），不属于任何模块时返回空字符串
+func moduleOf(relativePath string, modules []string) string {
+	module := ""
+	for _, dir := range modules {
+		if strings.HasPrefix(relativePath, dir+"/") && len(dir) > len(module) {
+			module = dir
+		}
+	}
+	return module
+}
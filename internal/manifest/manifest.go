@@ -0,0 +1,239 @@
+package manifest
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"maps"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/tuza/scaffolding-code-generation/internal/config"
+	"github.com/tuza/scaffolding-code-generation/internal/version"
+	"gopkg.in/yaml.v3"
+)
+
+// FileName 清单文件名，位于项目根目录
+const FileName = ".archi-gen.yaml"
+
+// BaseDir 原始生成文件快照目录（相对项目根目录），upgrade 以此作为三方合并的共同祖先
+const BaseDir = ".archi-gen/base"
+
+// TemplatesBuiltin 内置模板集
+const TemplatesBuiltin = "builtin"
+
+// Manifest 项目清单，记录生成项目时使用的工具版本、配置、模块和文件校验和
+type Manifest struct {
+	ToolVersion string            `yaml:"tool_version"`       // 生成/最近一次更新项目的 archi-gen 版本
+	Templates   string            `yaml:"templates"`          // 模板集：builtin 或用户模板目录（相对项目根目录）
+	Versions    map[string]string `yaml:"versions,omitempty"` // 覆盖内置版本目录的依赖版本（模块路径 -> 版本）
+	CreatedAt   time.Time         `yaml:"created_at"`         // 项目生成时间
+	UpdatedAt   time.Time         `yaml:"updated_at"`         // 最近一次更新时间
+
+	Config     Config            `yaml:"config"`     // 项目配置
+	Modules    []string          `yaml:"modules"`    // Go 模块目录（相对项目根目录）
+	Aggregates []Aggregate       `yaml:"aggregates"` // 聚合（限界上下文）
+	Files      map[string]string `yaml:"files"`      // 生成的文件 -> 内容校验和
+
+	// base 本次生成的文件内容，Save 时写入快照目录
+	base map[string][]byte
+}
+
+// Config 项目配置（不包含输出路径等与本机相关的信息）
+type Config struct {
+	ProjectName string          `yaml:"project_name"`
+	ModulePath  string          `yaml:"module_path"`
+	Language    config.Language `yaml:"language"`
+	UseRedis    bool            `yaml:"use_redis"`
+	Database    string          `yaml:"database"`
+	Framework   string          `yaml:"framework"`
+	Deployment  string          `yaml:"deployment"`
+}
+
+// Aggregate 聚合信息
+type Aggregate struct {
+	Name          string   `yaml:"name"`             // 聚合名称 (snake_case)
+	ErrorCodeBase int      `yaml:"error_code_base"`  // 错误码分段起始值
+	Fields        []string `yaml:"fields,omitempty"` // 聚合根字段定义（命令行格式）
+}
+
+// New 根据项目配置创建清单
+func New(cfg *config.ProjectConfig) *Manifest {
+	now := time.Now().UTC().Truncate(time.Second)
+	return &Manifest{
+		ToolVersion: version.Version,
+		Templates:   TemplatesBuiltin,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+		Config: Config{
+			ProjectName: cfg.ProjectName,
+			ModulePath:  cfg.ModulePath,
+			Language:    cfg.Language,
+			UseRedis:    cfg.UseRedis,
+			Database:    cfg.Database,
+			Framework:   cfg.Framework,
+			Deployment:  cfg.Deployment,
+		},
+		Files: make(map[string]string),
+		base:  make(map[string][]byte),
+	}
+}
+
+// Exists 判断项目目录中是否存在清单文件
+func Exists(projectDir string) bool {
+	_, err := os.Stat(filepath.Join(projectDir, FileName))
+	return err == nil
+}
+
+// Load 从项目目录读取清单
+func Load(projectDir string) (*Manifest, error) {
+	content, err := os.ReadFile(filepath.Join(projectDir, FileName))
+	if err != nil {
+		return nil, err
+	}
+
+	var m Manifest
+	if err := yaml.Unmarshal(content, &m); err != nil {
+		return nil, fmt.Errorf("解析 %s 失败: %w", FileName, err)
+	}
+	if m.Files == nil {
+		m.Files = make(map[string]string)
+	}
+	m.base = make(map[string][]byte)
+	return &m, nil
+}
+
+// Marshal 序列化清单
+func (m *Manifest) Marshal() ([]byte, error) {
+	content, err := yaml.Marshal(m)
+	if err != nil {
+		return nil, err
+	}
+	header := "# 由 archi-gen 生成和维护，请勿手动修改\n"
+	return append([]byte(header), content...), nil
+}
+
+// Save 将清单及本次生成文件的快照写入项目目录
+func (m *Manifest) Save(projectDir string) error {
+	return m.Export(func(name string, content []byte) error {
+		fullPath := filepath.Join(projectDir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(fullPath), 0755); err != nil {
+			return err
+		}
+		return os.WriteFile(fullPath, content, 0644)
+	})
+}
+
+// Export 通过 write 输出本次生成文件的快照和清单文件，路径相对项目根目录
+func (m *Manifest) Export(write func(name string, content []byte) error) error {
+	for _, relativePath := range slices.Sorted(maps.Keys(m.base)) {
+		if err := write(path.Join(BaseDir, relativePath), m.base[relativePath]); err != nil {
+			return err
+		}
+	}
+
+	content, err := m.Marshal()
+	if err != nil {
+		return err
+	}
+	return write(FileName, content)
+}
+
+// LoadBase 读取文件的原始生成快照，快照不存在时返回 false
+func LoadBase(projectDir, relativePath string) ([]byte, bool) {
+	content, err := os.ReadFile(filepath.Join(projectDir, BaseDir, filepath.FromSlash(relativePath)))
+	if err != nil {
+		return nil, false
+	}
+	return content, true
+}
+
+// ProjectConfig 还原项目配置，projectDir 为项目根目录
+func (m *Manifest) ProjectConfig(projectDir string) (*config.ProjectConfig, error) {
+	absDir, err := filepath.Abs(projectDir)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := config.NewProjectConfig()
+	cfg.ProjectName = m.Config.ProjectName
+	cfg.ModulePath = m.Config.ModulePath
+	cfg.Language = m.Config.Language
+	cfg.UseRedis = m.Config.UseRedis
+	if m.Config.Database != "" {
+		cfg.Database = m.Config.Database
+	}
+	if m.Config.Framework != "" {
+		cfg.Framework = m.Config.Framework
+	}
+	if m.Config.Deployment != "" {
+		cfg.Deployment = m.Config.Deployment
+	}
+	// 项目目录可能被移动，输出路径以实际位置为准
+	cfg.OutputPath = filepath.Dir(absDir)
+
+	return cfg, cfg.Validate()
+}
+
+// Touch 记录当前工具版本和更新时间
+func (m *Manifest) Touch() {
+	m.ToolVersion = version.Version
+	m.UpdatedAt = time.Now().UTC().Truncate(time.Second)
+}
+
+// AddModules 记录 Go 模块目录（去重）
+func (m *Manifest) AddModules(dirs ...string) {
+	for _, dir := range dirs {
+		if !slices.Contains(m.Modules, dir) {
+			m.Modules = append(m.Modules, dir)
+		}
+	}
+}
+
+// AddAggregate 记录聚合，同名聚合会被覆盖
+func (m *Manifest) AddAggregate(agg Aggregate) {
+	for i := range m.Aggregates {
+		if m.Aggregates[i].Name == agg.Name {
+			m.Aggregates[i] = agg
+			return
+		}
+	}
+	m.Aggregates = append(m.Aggregates, agg)
+}
+
+// NextErrorCodeBase 返回下一个可用的错误码分段起始值，没有记录任何聚合时返回 0
+func (m *Manifest) NextErrorCodeBase() int {
+	next := 0
+	for _, agg := range m.Aggregates {
+		next = max(next, agg.ErrorCodeBase+1000)
+	}
+	return next
+}
+
+// RecordFile 记录生成文件的校验和及快照
+func (m *Manifest) RecordFile(relativePath string, content []byte) {
+	relativePath = filepath.ToSlash(relativePath)
+	m.Files[relativePath] = Checksum(content)
+	m.base[relativePath] = content
+}
+
+// RetainFile 保留文件上一次生成时的记录，Save 时不覆盖该文件的快照
+// checksum 为上一次记录的校验和，为空表示上一次未生成该文件，此时不记录该文件
+func (m *Manifest) RetainFile(relativePath, checksum string) {
+	relativePath = filepath.ToSlash(relativePath)
+	delete(m.base, relativePath)
+	if checksum == "" {
+		delete(m.Files, relativePath)
+		return
+	}
+	m.Files[relativePath] = checksum
+}
+
+// Checksum 计算文件内容校验和
+func Checksum(content []byte) string {
+	sum := sha256.Sum256(content)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
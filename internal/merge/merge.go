@@ -0,0 +1,114 @@
+package merge
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/tuza/scaffolding-code-generation/internal/diff"
+)
+
+// Labels 冲突标记中三个版本的名称
+type Labels struct {
+	Current string // 用户当前版本
+	Base    string // 原始生成版本
+	New     string // 新生成版本
+}
+
+// Result 合并结果
+type Result struct {
+	Content   string // 合并后的内容（存在冲突时包含冲突标记）
+	Conflicts int    // 冲突数量
+}
+
+// ThreeWay 以 base 为共同祖先，对 current（用户修改）和 updated（新模板）进行三方合并（diff3）
+// 只有一方修改或双方修改相同的区域会自动合并，双方修改不同的区域以冲突标记输出
+func ThreeWay(base, current, updated string, labels Labels) Result {
+	baseLines := diff.SplitLines(base)
+	currentLines := diff.SplitLines(current)
+	updatedLines := diff.SplitLines(updated)
+
+	// base 中每一行在两个版本中对应的行号，未匹配为 -1
+	toCurrent := matchLines(baseLines, currentLines)
+	toUpdated := matchLines(baseLines, updatedLines)
+
+	var sb strings.Builder
+	conflicts := 0
+	i, j, k := 0, 0, 0
+	for i < len(baseLines) || j < len(currentLines) || k < len(updatedLines) {
+		// 三方同步的稳定行直接输出
+		if i < len(baseLines) && toCurrent[i] == j && toUpdated[i] == k {
+			sb.WriteString(baseLines[i])
+			i, j, k = i+1, j+1, k+1
+			continue
+		}
+
+		// 找到下一个三方同步的位置，中间为不稳定区域
+		ni := i
+		for ni < len(baseLines) && (toCurrent[ni] < j || toUpdated[ni] < k) {
+			ni++
+		}
+		nj, nk := len(currentLines), len(updatedLines)
+		if ni < len(baseLines) {
+			nj, nk = toCurrent[ni], toUpdated[ni]
+		}
+
+		baseChunk := baseLines[i:ni]
+		currentChunk := currentLines[j:nj]
+		updatedChunk := updatedLines[k:nk]
+		switch {
+		case slices.Equal(currentChunk, baseChunk):
+			writeAll(&sb, updatedChunk)
+		case slices.Equal(updatedChunk, baseChunk), slices.Equal(currentChunk, updatedChunk):
+			writeAll(&sb, currentChunk)
+		default:
+			conflicts++
+			writeConflict(&sb, labels, baseChunk, currentChunk, updatedChunk)
+		}
+		i, j, k = ni, nj, nk
+	}
+
+	return Result{Content: sb.String(), Conflicts: conflicts}
+}
+
+// matchLines 计算 a 中每一行在 b 中匹配的行号，未匹配为 -1
+func matchLines(a, b []string) []int {
+	match := make([]int, len(a))
+	for i := range match {
+		match[i] = -1
+	}
+	for _, op := range diff.Lines(a, b) {
+		if op.Kind != diff.Equal {
+			continue
+		}
+		for n := 0; n < op.A2-op.A1; n++ {
+			match[op.A1+n] = op.B1 + n
+		}
+	}
+	return match
+}
+
+// writeConflict 写入 diff3 风格的冲突标记
+func writeConflict(sb *strings.Builder, labels Labels, base, current, updated []string) {
+	sb.WriteString("<<<<<<< " + labels.Current + "\n")
+	writeBlock(sb, current)
+	sb.WriteString("||||||| " + labels.Base + "\n")
+	writeBlock(sb, base)
+	sb.WriteString("=======\n")
+	writeBlock(sb, updated)
+	sb.WriteString(">>>>>>> " + labels.New + "\n")
+}
+
+// writeAll 原样写入多行
+func writeAll(sb *strings.Builder, lines []string) {
+	for _, line := range lines {
+		sb.WriteString(line)
+	}
+}
+
+// writeBlock 写入冲突块中的多行，保证下一个冲突标记独占一行
+func writeBlock(sb *strings.Builder, lines []string) {
+	writeAll(sb, lines)
+	if n := len(lines); n > 0 && !strings.HasSuffix(lines[n-1], "\n") {
+		sb.WriteString("\n")
+	}
+}
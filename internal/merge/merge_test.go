@@ -0,0 +1,72 @@
+package merge
+
+import "testing"
+
+func TestThreeWay(t *testing.T) {
+	labels := Labels{Current: "current", Base: "base", New: "new"}
+	base := "a\nb\nc\n"
+
+	tests := []struct {
+		name      string
+		current   string
+		updated   string
+		want      string
+		conflicts int
+	}{
+		{
+			name:    "双方均未修改",
+			current: base,
+			updated: base,
+			want:    base,
+		},
+		{
+			name:    "只有用户修改",
+			current: "a\nB\nc\n",
+			updated: base,
+			want:    "a\nB\nc\n",
+		},
+		{
+			name:    "只有模板修改",
+			current: base,
+			updated: "a\nb\nc\nd\n",
+			want:    "a\nb\nc\nd\n",
+		},
+		{
+			name:    "双方修改不同区域",
+			current: "A\nb\nc\n",
+			updated: "a\nb\nC\n",
+			want:    "A\nb\nC\n",
+		},
+		{
+			name:    "双方修改相同",
+			current: "a\nB\nc\n",
+			updated: "a\nB\nc\n",
+			want:    "a\nB\nc\n",
+		},
+		{
+			name:      "双方修改同一区域",
+			current:   "a\nX\nc\n",
+			updated:   "a\nY\nc\n",
+			want:      "a\n<<<<<<< current\nX\n||||||| base\nb\n=======\nY\n>>>>>>> new\nc\n",
+			conflicts: 1,
+		},
+		{
+			name:      "冲突块末尾没有换行",
+			current:   "a\nb\nX",
+			updated:   "a\nb\nY",
+			want:      "a\nb\n<<<<<<< current\nX\n||||||| base\nc\n=======\nY\n>>>>>>> new\n",
+			conflicts: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ThreeWay(base, tt.current, tt.updated, labels)
+			if got.Content != tt.want {
+				t.Errorf("Content =\n%s\nwant\n%s", got.Content, tt.want)
+			}
+			if got.Conflicts != tt.conflicts {
+				t.Errorf("Conflicts = %d, want %d", got.Conflicts, tt.conflicts)
+			}
+		})
+	}
+}
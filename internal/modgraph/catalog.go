@@ -0,0 +1,120 @@
+package modgraph
+
+import (
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+
+	"golang.org/x/mod/module"
+	"gopkg.in/yaml.v3"
+)
+
+// Dependency 版本目录中的第三方模块
+type Dependency struct {
+	Path     string   // 模块路径
+	Version  string   // 版本
+	Packages []string // bom/bom.go 中空导入的包，保证 go mod tidy 不会从 bom/go.mod 中移除该模块
+}
+
+// builtinCatalog 内置版本目录，生成项目中出现的全部第三方模块都必须在这里登记
+var builtinCatalog = []Dependency{
+	{"github.com/bytedance/sonic", "v1.12.6", []string{"github.com/bytedance/sonic"}},
+	{"github.com/cloudwego/hertz", "v0.9.3", []string{
+		"github.com/cloudwego/hertz/pkg/app",
+		"github.com/cloudwego/hertz/pkg/app/server",
+		"github.com/cloudwego/hertz/pkg/common/hlog",
+		"github.com/cloudwego/hertz/pkg/protocol/consts",
+	}},
+	{"github.com/cloudwego/kitex", "v0.11.3", []string{
+		"github.com/cloudwego/kitex/client",
+		"github.com/cloudwego/kitex/pkg/klog",
+		"github.com/cloudwego/kitex/server",
+	}},
+	{"github.com/gin-gonic/gin", "v1.10.0", []string{"github.com/gin-gonic/gin"}},
+	{"github.com/go-chi/chi/v5", "v5.2.0", []string{"github.com/go-chi/chi/v5", "github.com/go-chi/chi/v5/middleware"}},
+	{"github.com/go-playground/validator/v10", "v10.23.0", []string{"github.com/go-playground/validator/v10"}},
+	{"github.com/google/uuid", "v1.6.0", []string{"github.com/google/uuid"}},
+	{"github.com/redis/go-redis/v9", "v9.7.0", []string{"github.com/redis/go-redis/v9"}},
+	{"github.com/shopspring/decimal", "v1.4.0", []string{"github.com/shopspring/decimal"}},
+	{"github.com/spf13/viper", "v1.19.0", []string{"github.com/spf13/viper"}},
+	{"golang.org/x/crypto", "v0.23.0", []string{"golang.org/x/crypto/bcrypt"}},
+	{"gorm.io/driver/mysql", "v1.5.7", []string{"gorm.io/driver/mysql"}},
+	{"gorm.io/driver/postgres", "v1.5.11", []string{"gorm.io/driver/postgres"}},
+	{"gorm.io/driver/sqlite", "v1.5.7", []string{"gorm.io/driver/sqlite"}},
+	{"gorm.io/gorm", "v1.25.12", []string{"gorm.io/gorm"}},
+}
+
+// Catalog 版本目录：生成项目中全部第三方模块的版本
+// 各模块 go.mod 的 require 版本以及 bom/go.mod 都由它派生
+type Catalog struct {
+	deps map[string]Dependency
+}
+
+// DefaultCatalog 返回内置版本目录
+func DefaultCatalog() *Catalog {
+	c := &Catalog{deps: make(map[string]Dependency, len(builtinCatalog))}
+	for _, dep := range builtinCatalog {
+		c.deps[dep.Path] = dep
+	}
+	return c
+}
+
+// NewCatalog 返回以 overrides（模块路径 -> 版本）覆盖内置版本后的版本目录
+func NewCatalog(overrides map[string]string) (*Catalog, error) {
+	c := DefaultCatalog()
+	for _, path := range slices.Sorted(maps.Keys(overrides)) {
+		dep, ok := c.deps[path]
+		if !ok {
+			return nil, fmt.Errorf("依赖 %s 不在版本目录中，可覆盖的依赖: %s", path, strings.Join(c.paths(), ", "))
+		}
+		version := overrides[path]
+		if err := module.Check(path, version); err != nil {
+			return nil, fmt.Errorf("依赖 %s 的版本无效: %w", path, err)
+		}
+		dep.Version = version
+		c.deps[path] = dep
+	}
+	return c, nil
+}
+
+// LoadVersions 读取用户版本文件，文件内容为 模块路径: 版本 的 YAML 映射，例如：
+//
+//	github.com/cloudwego/hertz: v0.10.0
+//	gorm.io/gorm: v1.26.0
+func LoadVersions(path string) (map[string]string, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var versions map[string]string
+	if err := yaml.Unmarshal(content, &versions); err != nil {
+		return nil, fmt.Errorf("解析版本文件 '%s' 失败: %w", path, err)
+	}
+	if _, err := NewCatalog(versions); err != nil {
+		return nil, fmt.Errorf("版本文件 '%s' 无效: %w", path, err)
+	}
+	return versions, nil
+}
+
+// Version 返回模块的版本
+func (c *Catalog) Version(path string) (string, bool) {
+	dep, ok := c.deps[path]
+	return dep.Version, ok
+}
+
+// Packages 返回 paths 中各模块需要在 bom/bom.go 中空导入的包（已排序）
+func (c *Catalog) Packages(paths []string) []string {
+	var pkgs []string
+	for _, path := range paths {
+		pkgs = append(pkgs, c.deps[path].Packages...)
+	}
+	slices.Sort(pkgs)
+	return slices.Compact(pkgs)
+}
+
+// paths 返回版本目录中的全部模块路径（已排序）
+func (c *Catalog) paths() []string {
+	return slices.Sorted(maps.Keys(c.deps))
+}
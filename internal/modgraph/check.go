@@ -0,0 +1,63 @@
+package modgraph
+
+import (
+	"cmp"
+	"maps"
+	"slices"
+	"strings"
+
+	"golang.org/x/mod/modfile"
+	"golang.org/x/mod/semver"
+)
+
+// Drift 模块 go.mod 中与 bom/go.mod 不一致的依赖版本
+type Drift struct {
+	Module     string // 模块目录
+	Path       string // 依赖的模块路径
+	Version    string // 模块 go.mod 中的版本
+	BOMVersion string // bom/go.mod 中的版本
+	Indirect   bool   // 是否为间接依赖
+}
+
+// Newer 模块中的版本是否高于 BOM（高于时 BOM 不再约束该依赖）
+func (d Drift) Newer() bool {
+	return semver.Compare(d.Version, d.BOMVersion) > 0
+}
+
+// CheckBOM 对比各模块 go.mod（模块目录 -> 内容）与 bom/go.mod 中的 require 版本，
+// 返回版本不一致的依赖，按模块目录和依赖路径排序；BOM 中没有的依赖不检查
+func CheckBOM(bom []byte, modules map[string][]byte) ([]Drift, error) {
+	bomFile, err := modfile.ParseLax("bom/go.mod", bom, nil)
+	if err != nil {
+		return nil, err
+	}
+	versions := make(map[string]string, len(bomFile.Require))
+	for _, r := range bomFile.Require {
+		versions[r.Mod.Path] = r.Mod.Version
+	}
+
+	var drifts []Drift
+	for _, dir := range slices.Sorted(maps.Keys(modules)) {
+		f, err := modfile.ParseLax(dir+"/go.mod", modules[dir], nil)
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range f.Require {
+			want, ok := versions[r.Mod.Path]
+			if !ok || r.Mod.Version == want {
+				continue
+			}
+			drifts = append(drifts, Drift{
+				Module:     dir,
+				Path:       r.Mod.Path,
+				Version:    r.Mod.Version,
+				BOMVersion: want,
+				Indirect:   r.Indirect,
+			})
+		}
+	}
+	slices.SortStableFunc(drifts, func(a, b Drift) int {
+		return cmp.Or(strings.Compare(a.Module, b.Module), strings.Compare(a.Path, b.Path))
+	})
+	return drifts, nil
+}
@@ -0,0 +1,357 @@
+// Package modgraph 描述生成项目中各 Go 模块之间的依赖关系，
+// 并据此计算每个模块 go.mod 中的 require / replace 以及 go.work 中的 use
+package modgraph
+
+import (
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+
+	"golang.org/x/mod/modfile"
+)
+
+// GoVersion 生成的 go.mod / go.work 中的 go 指令版本
+const GoVersion = "1.24.11"
+
+// localVersion 内部模块在 require 中使用的占位版本，实际通过 replace 指向本地目录
+const localVersion = "v0.0.0"
+
+// Module 项目中的一个 Go 模块
+type Module struct {
+	Dir      string   // 模块目录（相对项目根目录），例如 user/domain
+	Requires []string // 依赖的内部模块目录
+	Deps     []string // 依赖的第三方模块路径，版本取自版本目录
+}
+
+// Graph 项目的模块依赖图
+type Graph struct {
+	modulePath string
+	catalog    *Catalog
+	bom        string // BOM 模块目录
+	modules    []*Module
+}
+
+// New 创建模块依赖图，modulePath 为项目模块路径，内部模块的路径为 modulePath/Dir
+// 第三方模块的版本取自 catalog
+func New(modulePath string, catalog *Catalog) *Graph {
+	return &Graph{modulePath: modulePath, catalog: catalog}
+}
+
+// SetBOM 将 dir 设为 BOM 模块：它的 go.mod 依赖图中全部模块用到的第三方模块，
+// 其他模块依赖 BOM 后，构建时的版本不会低于 BOM 中的版本
+func (g *Graph) SetBOM(dir string) {
+	g.bom = dir
+}
+
+// BOMDeps 返回 BOM 模块依赖的第三方模块：BOM 自身声明的和其他模块用到的全部第三方模块（已排序）
+func (g *Graph) BOMDeps() []string {
+	var deps []string
+	for _, m := range g.modules {
+		deps = append(deps, m.Deps...)
+	}
+	slices.Sort(deps)
+	return slices.Compact(deps)
+}
+
+// BOMPackages 返回 BOM 模块需要空导入的包（已排序）
+func (g *Graph) BOMPackages() []string {
+	return g.catalog.Packages(g.BOMDeps())
+}
+
+// Add 加入模块，目录已存在时返回错误
+func (g *Graph) Add(modules ...Module) error {
+	for _, m := range modules {
+		if g.Module(m.Dir) != nil {
+			return fmt.Errorf("模块 '%s' 重复声明", m.Dir)
+		}
+		g.modules = append(g.modules, &m)
+	}
+	return nil
+}
+
+// Require 为已有模块追加对内部模块的依赖（例如新增聚合后 cmd/api 依赖新的模块）
+func (g *Graph) Require(dir string, requires ...string) error {
+	m := g.Module(dir)
+	if m == nil {
+		return fmt.Errorf("模块 '%s' 不存在", dir)
+	}
+	for _, req := range requires {
+		if !slices.Contains(m.Requires, req) {
+			m.Requires = append(m.Requires, req)
+		}
+	}
+	return nil
+}
+
+// Module 返回目录对应的模块，不存在时返回 nil
+func (g *Graph) Module(dir string) *Module {
+	for _, m := range g.modules {
+		if m.Dir == dir {
+			return m
+		}
+	}
+	return nil
+}
+
+// ModulePath 返回内部模块的模块路径
+func (g *Graph) ModulePath(dir string) string {
+	return g.modulePath + "/" + dir
+}
+
+// Dirs 按依赖顺序返回全部模块目录：被依赖的模块在前，其余保持声明顺序
+// 依赖了未声明的模块或存在循环依赖时返回错误
+func (g *Graph) Dirs() ([]string, error) {
+	const (
+		visiting = 1
+		done     = 2
+	)
+	state := make(map[string]int, len(g.modules))
+	dirs := make([]string, 0, len(g.modules))
+
+	var visit func(m *Module, from string) error
+	visit = func(m *Module, from string) error {
+		switch state[m.Dir] {
+		case done:
+			return nil
+		case visiting:
+			return fmt.Errorf("模块 '%s' 与 '%s' 存在循环依赖", from, m.Dir)
+		}
+		state[m.Dir] = visiting
+		for _, req := range m.Requires {
+			dep := g.Module(req)
+			if dep == nil {
+				return fmt.Errorf("模块 '%s' 依赖的模块 '%s' 不存在", m.Dir, req)
+			}
+			if err := visit(dep, m.Dir); err != nil {
+				return err
+			}
+		}
+		state[m.Dir] = done
+		dirs = append(dirs, m.Dir)
+		return nil
+	}
+
+	for _, m := range g.modules {
+		if err := visit(m, m.Dir); err != nil {
+			return nil, err
+		}
+	}
+	return dirs, nil
+}
+
+// closure 返回模块直接和间接依赖的全部内部模块，按依赖顺序排列
+// 非工作区构建时只有主模块的 replace 生效，因此间接依赖也需要 replace
+func (g *Graph) closure(dir string) ([]string, error) {
+	all, err := g.Dirs()
+	if err != nil {
+		return nil, err
+	}
+
+	needed := make(map[string]bool)
+	var mark func(dir string)
+	mark = func(dir string) {
+		for _, req := range g.Module(dir).Requires {
+			if !needed[req] {
+				needed[req] = true
+				mark(req)
+			}
+		}
+	}
+	mark(dir)
+
+	var deps []string
+	for _, d := range all {
+		if needed[d] {
+			deps = append(deps, d)
+		}
+	}
+	return deps, nil
+}
+
+// GoMod 生成模块的 go.mod：
+// require 为直接依赖的内部模块和第三方模块，replace 将全部（含间接）依赖的内部模块指向本地目录
+func (g *Graph) GoMod(dir string) ([]byte, error) {
+	m := g.Module(dir)
+	if m == nil {
+		return nil, fmt.Errorf("模块 '%s' 不存在", dir)
+	}
+
+	f := new(modfile.File)
+	if err := f.AddModuleStmt(g.ModulePath(dir)); err != nil {
+		return nil, err
+	}
+	if err := f.AddGoStmt(GoVersion); err != nil {
+		return nil, err
+	}
+	if _, err := g.merge(f, m); err != nil {
+		return nil, err
+	}
+	return f.Format()
+}
+
+// MergeGoMod 将模块在依赖图中的 require / replace 合并到已有的 go.mod，保留文件中的其他内容
+// changed 表示是否有新增的条目
+func (g *Graph) MergeGoMod(dir string, content []byte) (updated []byte, changed bool, err error) {
+	m := g.Module(dir)
+	if m == nil {
+		return nil, false, fmt.Errorf("模块 '%s' 不存在", dir)
+	}
+
+	filename := path.Join(dir, "go.mod")
+	f, err := modfile.Parse(filename, content, nil)
+	if err != nil {
+		return nil, false, err
+	}
+	added, err := g.merge(f, m)
+	if err != nil {
+		return nil, false, err
+	}
+	if added == 0 {
+		return content, false, nil
+	}
+
+	if dir == g.bom {
+		// BOM 的依赖按路径排序，便于查阅
+		f.SortBlocks()
+	}
+	f.Cleanup()
+	updated, err = f.Format()
+	return updated, true, err
+}
+
+// merge 为 f 加入模块 m 缺少的 require 和 replace，返回新增的条目数
+func (g *Graph) merge(f *modfile.File, m *Module) (int, error) {
+	required := make(map[string]bool, len(f.Require))
+	for _, r := range f.Require {
+		required[r.Mod.Path] = true
+	}
+	replaced := make(map[string]bool, len(f.Replace))
+	for _, r := range f.Replace {
+		replaced[r.Old.Path] = true
+	}
+
+	added := 0
+	for _, req := range m.Requires {
+		if p := g.ModulePath(req); !required[p] {
+			f.AddNewRequire(p, localVersion, false)
+			added++
+		}
+	}
+	deps := slices.Sorted(slices.Values(m.Deps))
+	if m.Dir == g.bom {
+		deps = g.BOMDeps()
+	}
+	for _, dep := range deps {
+		if required[dep] {
+			continue
+		}
+		version, ok := g.catalog.Version(dep)
+		if !ok {
+			return 0, fmt.Errorf("模块 '%s' 依赖的 %s 不在版本目录中", m.Dir, dep)
+		}
+		f.AddNewRequire(dep, version, false)
+		added++
+	}
+
+	internal, err := g.closure(m.Dir)
+	if err != nil {
+		return 0, err
+	}
+	for _, dep := range internal {
+		if p := g.ModulePath(dep); !replaced[p] {
+			addReplace(f, p, relativeDir(m.Dir, dep))
+			added++
+		}
+	}
+	return added, nil
+}
+
+// addReplace 加入 replace 指令，已有 replace 块时追加到块中，单行 replace 转换为块
+// modfile.File.AddReplace 总是另起一行，这里保持 replace 集中在一个块中
+func addReplace(f *modfile.File, oldPath, newPath string) {
+	tokens := []string{modfile.AutoQuote(oldPath), "=>", modfile.AutoQuote(newPath)}
+	for i := len(f.Syntax.Stmt) - 1; i >= 0; i-- {
+		switch stmt := f.Syntax.Stmt[i].(type) {
+		case *modfile.LineBlock:
+			if len(stmt.Token) > 0 && stmt.Token[0] == "replace" {
+				stmt.Line = append(stmt.Line, &modfile.Line{Token: tokens, InBlock: true})
+				return
+			}
+		case *modfile.Line:
+			if len(stmt.Token) > 0 && stmt.Token[0] == "replace" {
+				f.Syntax.Stmt[i] = &modfile.LineBlock{
+					Comments: stmt.Comments,
+					Token:    []string{"replace"},
+					Line: []*modfile.Line{
+						{Token: stmt.Token[1:], InBlock: true},
+						{Token: tokens, InBlock: true},
+					},
+				}
+				return
+			}
+		}
+	}
+
+	f.Syntax.Stmt = append(f.Syntax.Stmt, &modfile.Line{Token: append([]string{"replace"}, tokens...)})
+}
+
+// relativeDir 返回从模块 from 指向模块 to 的相对目录，例如 user/infrastructure → user/domain 为 ../domain
+func relativeDir(from, to string) string {
+	fromParts, toParts := strings.Split(from, "/"), strings.Split(to, "/")
+	common := 0
+	for common < len(fromParts) && common < len(toParts) && fromParts[common] == toParts[common] {
+		common++
+	}
+	rest := strings.Join(toParts[common:], "/")
+	if common == len(fromParts) {
+		return "./" + rest
+	}
+	return strings.Repeat("../", len(fromParts)-common) + rest
+}
+
+// GoWork 生成 go.work，use 按依赖顺序列出全部模块
+func (g *Graph) GoWork() ([]byte, error) {
+	dirs, err := g.Dirs()
+	if err != nil {
+		return nil, err
+	}
+
+	f := &modfile.WorkFile{Syntax: new(modfile.FileSyntax)}
+	if err := f.AddGoStmt(GoVersion); err != nil {
+		return nil, err
+	}
+	for _, dir := range dirs {
+		if err := f.AddUse("./"+dir, ""); err != nil {
+			return nil, err
+		}
+	}
+	f.Cleanup()
+	return modfile.Format(f.Syntax), nil
+}
+
+// MergeGoWork 将依赖图中缺少的模块加入已有的 go.work
+func (g *Graph) MergeGoWork(content []byte) ([]byte, error) {
+	f, err := modfile.ParseWork("go.work", content, nil)
+	if err != nil {
+		return nil, err
+	}
+	dirs, err := g.Dirs()
+	if err != nil {
+		return nil, err
+	}
+
+	used := make(map[string]bool, len(f.Use))
+	for _, use := range f.Use {
+		used[strings.TrimPrefix(use.Path, "./")] = true
+	}
+	for _, dir := range dirs {
+		if !used[dir] {
+			if err := f.AddUse("./"+dir, ""); err != nil {
+				return nil, err
+			}
+		}
+	}
+	f.Cleanup()
+	return modfile.Format(f.Syntax), nil
+}
@@ -0,0 +1,171 @@
+package modgraph
+
+import (
+	"strings"
+	"testing"
+)
+
+// testGraph 返回测试用的依赖图，模块故意不按依赖顺序声明
+func testGraph(t *testing.T) *Graph {
+	t.Helper()
+	g := New("example.com/demo", DefaultCatalog())
+	g.SetBOM("bom")
+	err := g.Add(
+		Module{Dir: "cmd/api", Requires: []string{"user/infrastructure"}, Deps: []string{"github.com/cloudwego/hertz"}},
+		Module{Dir: "user/infrastructure", Requires: []string{"user/domain", "share"}, Deps: []string{"gorm.io/gorm", "github.com/google/uuid"}},
+		Module{Dir: "user/domain", Requires: []string{"bom", "share"}, Deps: []string{"github.com/google/uuid"}},
+		Module{Dir: "share", Requires: []string{"bom"}},
+		Module{Dir: "bom"},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return g
+}
+
+func TestGraphGoMod(t *testing.T) {
+	tests := []struct {
+		dir  string
+		want string
+	}{
+		{
+			// 间接依赖的内部模块同样需要 replace
+			dir: "cmd/api",
+			want: `module example.com/demo/cmd/api
+
+go 1.24.11
+
+require (
+	example.com/demo/user/infrastructure v0.0.0
+	github.com/cloudwego/hertz v0.9.3
+)
+
+replace (
+	example.com/demo/bom => ../../bom
+	example.com/demo/share => ../../share
+	example.com/demo/user/domain => ../../user/domain
+	example.com/demo/user/infrastructure => ../../user/infrastructure
+)
+`,
+		},
+		{
+			// 第三方模块按路径排序，replace 使用相对模块目录的路径
+			dir: "user/infrastructure",
+			want: `module example.com/demo/user/infrastructure
+
+go 1.24.11
+
+require (
+	example.com/demo/user/domain v0.0.0
+	example.com/demo/share v0.0.0
+	github.com/google/uuid v1.6.0
+	gorm.io/gorm v1.25.12
+)
+
+replace (
+	example.com/demo/bom => ../../bom
+	example.com/demo/share => ../../share
+	example.com/demo/user/domain => ../domain
+)
+`,
+		},
+		{
+			// BOM 依赖全部模块用到的第三方模块
+			dir: "bom",
+			want: `module example.com/demo/bom
+
+go 1.24.11
+
+require (
+	github.com/cloudwego/hertz v0.9.3
+	github.com/google/uuid v1.6.0
+	gorm.io/gorm v1.25.12
+)
+`,
+		},
+	}
+	g := testGraph(t)
+	for _, tt := range tests {
+		t.Run(tt.dir, func(t *testing.T) {
+			got, err := g.GoMod(tt.dir)
+			if err != nil {
+				t.Fatalf("GoMod(%q) error = %v", tt.dir, err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("GoMod(%q) =\n%s\nwant\n%s", tt.dir, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGraphGoWork(t *testing.T) {
+	got, err := testGraph(t).GoWork()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 被依赖的模块在前
+	want := `go 1.24.11
+
+use (
+	./bom
+	./share
+	./user/domain
+	./user/infrastructure
+	./cmd/api
+)
+`
+	if string(got) != want {
+		t.Errorf("GoWork() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestGraphErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		modules []Module
+		dir     string
+		wantErr string
+	}{
+		{
+			name:    "模块不存在",
+			modules: []Module{{Dir: "share"}},
+			dir:     "user/domain",
+			wantErr: "不存在",
+		},
+		{
+			name:    "依赖未声明的模块",
+			modules: []Module{{Dir: "share", Requires: []string{"bom"}}},
+			dir:     "share",
+			wantErr: "依赖的模块 'bom' 不存在",
+		},
+		{
+			name:    "循环依赖",
+			modules: []Module{{Dir: "a", Requires: []string{"b"}}, {Dir: "b", Requires: []string{"a"}}},
+			dir:     "a",
+			wantErr: "循环依赖",
+		},
+		{
+			name:    "第三方模块不在版本目录中",
+			modules: []Module{{Dir: "share", Deps: []string{"example.org/unknown"}}},
+			dir:     "share",
+			wantErr: "不在版本目录中",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := New("example.com/demo", DefaultCatalog())
+			if err := g.Add(tt.modules...); err != nil {
+				t.Fatal(err)
+			}
+			_, err := g.GoMod(tt.dir)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("GoMod(%q) error = %v, want error containing %q", tt.dir, err, tt.wantErr)
+			}
+		})
+	}
+
+	g := New("example.com/demo", DefaultCatalog())
+	if err := g.Add(Module{Dir: "share"}, Module{Dir: "share"}); err == nil {
+		t.Error("Add() 重复的模块应返回错误")
+	}
+}
@@ -0,0 +1,194 @@
+package output
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"io/fs"
+	"path"
+	"slices"
+	"strings"
+	"time"
+)
+
+// ArchiveFormat 归档格式
+type ArchiveFormat string
+
+const (
+	FormatTarGz ArchiveFormat = "tar.gz"
+	FormatZip   ArchiveFormat = "zip"
+)
+
+// ArchiveFormats 支持的归档格式
+var ArchiveFormats = []ArchiveFormat{FormatTarGz, FormatZip}
+
+// ParseArchiveFormat 解析归档格式，支持 tgz 作为 tar.gz 的别名
+func ParseArchiveFormat(s string) (ArchiveFormat, error) {
+	switch strings.ToLower(s) {
+	case "tar.gz", "tgz":
+		return FormatTarGz, nil
+	case "zip":
+		return FormatZip, nil
+	default:
+		return "", fmt.Errorf("不支持的归档格式 '%s'（可选: tar.gz, zip）", s)
+	}
+}
+
+// ArchiveFS 以 tar.gz 或 zip 流的形式输出，文件写入时立即追加到归档中
+// 所有条目位于 prefix 目录下（通常为项目名称），写入完成后必须调用 Close
+type ArchiveFS struct {
+	format  ArchiveFormat
+	prefix  string
+	modTime time.Time
+
+	gz *gzip.Writer
+	tw *tar.Writer
+	zw *zip.Writer
+
+	// 已写入的文件和目录，用于 ReadFile/Exists 以及避免重复的目录条目
+	files map[string][]byte
+	dirs  map[string]bool
+}
+
+// NewArchiveFS 创建写入 w 的归档输出
+func NewArchiveFS(w io.Writer, format ArchiveFormat, prefix string) (*ArchiveFS, error) {
+	a := &ArchiveFS{
+		format:  format,
+		prefix:  strings.Trim(prefix, "/"),
+		modTime: time.Now().Truncate(time.Second),
+		files:   make(map[string][]byte),
+		dirs:    make(map[string]bool),
+	}
+
+	switch format {
+	case FormatTarGz:
+		a.gz = gzip.NewWriter(w)
+		a.tw = tar.NewWriter(a.gz)
+	case FormatZip:
+		a.zw = zip.NewWriter(w)
+	default:
+		return nil, fmt.Errorf("不支持的归档格式 '%s'", format)
+	}
+
+	if a.prefix != "" {
+		if err := a.writeDir(""); err != nil {
+			return nil, err
+		}
+	}
+	return a, nil
+}
+
+// entryName 返回归档中的条目名称
+func (a *ArchiveFS) entryName(name string) string {
+	return strings.TrimPrefix(path.Join(a.prefix, name), "/")
+}
+
+// WriteFile 写入文件，同一文件只能写入一次
+func (a *ArchiveFS) WriteFile(name string, content []byte) error {
+	name = path.Clean(name)
+	if _, ok := a.files[name]; ok {
+		return fmt.Errorf("归档中已存在文件 %s", name)
+	}
+	if err := a.MkdirAll(path.Dir(name)); err != nil {
+		return err
+	}
+
+	entry := a.entryName(name)
+	switch a.format {
+	case FormatTarGz:
+		header := &tar.Header{
+			Typeflag: tar.TypeReg,
+			Name:     entry,
+			Mode:     0644,
+			Size:     int64(len(content)),
+			ModTime:  a.modTime,
+		}
+		if err := a.tw.WriteHeader(header); err != nil {
+			return err
+		}
+		if _, err := a.tw.Write(content); err != nil {
+			return err
+		}
+	case FormatZip:
+		header := &zip.FileHeader{Name: entry, Method: zip.Deflate, Modified: a.modTime}
+		header.SetMode(0644)
+		w, err := a.zw.CreateHeader(header)
+		if err != nil {
+			return err
+		}
+		if _, err := w.Write(content); err != nil {
+			return err
+		}
+	}
+
+	a.files[name] = slices.Clone(content)
+	return nil
+}
+
+// ReadFile 读取已写入归档的文件
+func (a *ArchiveFS) ReadFile(name string) ([]byte, error) {
+	content, ok := a.files[path.Clean(name)]
+	if !ok {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+	}
+	return slices.Clone(content), nil
+}
+
+// Exists 判断文件或目录是否已写入归档
+func (a *ArchiveFS) Exists(name string) bool {
+	name = path.Clean(name)
+	_, ok := a.files[name]
+	return ok || a.dirs[name]
+}
+
+// MkdirAll 写入目录及其上级目录的条目
+func (a *ArchiveFS) MkdirAll(name string) error {
+	name = path.Clean(name)
+	if name == "." || a.dirs[name] {
+		return nil
+	}
+	if err := a.MkdirAll(path.Dir(name)); err != nil {
+		return err
+	}
+	if err := a.writeDir(name); err != nil {
+		return err
+	}
+	a.dirs[name] = true
+	return nil
+}
+
+// writeDir 写入目录条目
+func (a *ArchiveFS) writeDir(name string) error {
+	entry := a.entryName(name) + "/"
+	switch a.format {
+	case FormatTarGz:
+		return a.tw.WriteHeader(&tar.Header{
+			Typeflag: tar.TypeDir,
+			Name:     entry,
+			Mode:     0755,
+			ModTime:  a.modTime,
+		})
+	case FormatZip:
+		header := &zip.FileHeader{Name: entry, Modified: a.modTime}
+		header.SetMode(fs.ModeDir | 0755)
+		_, err := a.zw.CreateHeader(header)
+		return err
+	}
+	return nil
+}
+
+// Close 写入归档结尾，不会关闭底层的 io.Writer
+func (a *ArchiveFS) Close() error {
+	switch a.format {
+	case FormatTarGz:
+		if err := a.tw.Close(); err != nil {
+			return err
+		}
+		return a.gz.Close()
+	case FormatZip:
+		return a.zw.Close()
+	}
+	return nil
+}
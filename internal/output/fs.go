@@ -0,0 +1,120 @@
+package output
+
+import (
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// FS 生成结果的输出目标，路径均为相对项目根目录的 slash 路径
+type FS interface {
+	// WriteFile 写入文件，自动创建上级目录
+	WriteFile(name string, content []byte) error
+	// ReadFile 读取文件
+	ReadFile(name string) ([]byte, error)
+	// Exists 判断文件或目录是否存在
+	Exists(name string) bool
+	// MkdirAll 创建目录
+	MkdirAll(name string) error
+}
+
+// DirFS 写入本地目录
+type DirFS struct {
+	root string
+}
+
+// NewDirFS 创建以 root 为根目录的输出
+func NewDirFS(root string) *DirFS {
+	return &DirFS{root: root}
+}
+
+// Root 返回根目录
+func (d *DirFS) Root() string {
+	return d.root
+}
+
+func (d *DirFS) path(name string) string {
+	return filepath.Join(d.root, filepath.FromSlash(name))
+}
+
+// WriteFile 写入文件
+func (d *DirFS) WriteFile(name string, content []byte) error {
+	fullPath := d.path(name)
+	if err := os.MkdirAll(filepath.Dir(fullPath), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(fullPath, content, 0644)
+}
+
+// ReadFile 读取文件
+func (d *DirFS) ReadFile(name string) ([]byte, error) {
+	return os.ReadFile(d.path(name))
+}
+
+// Exists 判断文件或目录是否存在
+func (d *DirFS) Exists(name string) bool {
+	_, err := os.Stat(d.path(name))
+	return err == nil
+}
+
+// MkdirAll 创建目录
+func (d *DirFS) MkdirAll(name string) error {
+	return os.MkdirAll(d.path(name), 0755)
+}
+
+// MemFS 写入内存，用于 dry-run、diff、upgrade 以及检查生成结果
+type MemFS struct {
+	files map[string][]byte
+}
+
+// NewMemFS 创建内存输出
+func NewMemFS() *MemFS {
+	return &MemFS{files: make(map[string][]byte)}
+}
+
+// WriteFile 写入文件
+func (m *MemFS) WriteFile(name string, content []byte) error {
+	m.files[path.Clean(name)] = slices.Clone(content)
+	return nil
+}
+
+// ReadFile 读取文件
+func (m *MemFS) ReadFile(name string) ([]byte, error) {
+	content, ok := m.files[path.Clean(name)]
+	if !ok {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+	}
+	return slices.Clone(content), nil
+}
+
+// Exists 判断文件或目录是否存在
+func (m *MemFS) Exists(name string) bool {
+	name = path.Clean(name)
+	if _, ok := m.files[name]; ok {
+		return true
+	}
+	for file := range m.files {
+		if strings.HasPrefix(file, name+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// MkdirAll 内存输出不记录空目录
+func (m *MemFS) MkdirAll(name string) error {
+	return nil
+}
+
+// Paths 返回排序后的全部文件路径
+func (m *MemFS) Paths() []string {
+	paths := make([]string, 0, len(m.files))
+	for name := range m.files {
+		paths = append(paths, name)
+	}
+	slices.Sort(paths)
+	return paths
+}
@@ -0,0 +1,114 @@
+package output
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path"
+)
+
+// overlayTempSuffix 提交时临时文件的后缀
+const overlayTempSuffix = ".archi-gen-tmp"
+
+// OverlayFS 在已有目录上叠加的输出，用于修改已有项目（例如 add aggregate）
+// 写入先保存在内存中，读取优先返回已写入的内容；Commit 时才写入目录，Rollback 丢弃全部写入，目录保持不变
+type OverlayFS struct {
+	dir     *DirFS
+	pending *MemFS
+}
+
+// NewOverlayFS 创建叠加在 root 目录上的输出
+func NewOverlayFS(root string) *OverlayFS {
+	return &OverlayFS{
+		dir:     NewDirFS(root),
+		pending: NewMemFS(),
+	}
+}
+
+// Root 返回目录
+func (o *OverlayFS) Root() string {
+	return o.dir.Root()
+}
+
+// WriteFile 写入文件（提交前只保存在内存中）
+func (o *OverlayFS) WriteFile(name string, content []byte) error {
+	return o.pending.WriteFile(name, content)
+}
+
+// ReadFile 读取文件，优先返回尚未提交的写入
+func (o *OverlayFS) ReadFile(name string) ([]byte, error) {
+	if content, err := o.pending.ReadFile(name); err == nil {
+		return content, nil
+	}
+	return o.dir.ReadFile(name)
+}
+
+// Exists 判断文件或目录是否存在于目录或尚未提交的写入中
+func (o *OverlayFS) Exists(name string) bool {
+	return o.pending.Exists(name) || o.dir.Exists(name)
+}
+
+// MkdirAll 目录在提交写入文件时创建
+func (o *OverlayFS) MkdirAll(name string) error {
+	return nil
+}
+
+// Commit 将全部写入应用到目录
+// 先把每个文件写入同目录下的临时文件，全部成功后再逐个重命名覆盖；写入临时文件失败时删除临时文件和新建的目录，目录保持不变
+func (o *OverlayFS) Commit() error {
+	paths := o.pending.Paths()
+	temps := make([]string, 0, len(paths))
+	var createdDirs []string
+	cleanup := func() {
+		for _, tmp := range temps {
+			os.Remove(tmp)
+		}
+		for _, dir := range createdDirs {
+			os.RemoveAll(dir)
+		}
+	}
+
+	for _, name := range paths {
+		content, err := o.pending.ReadFile(name)
+		if err != nil {
+			cleanup()
+			return err
+		}
+		if dir := o.missingDir(name); dir != "" {
+			createdDirs = append(createdDirs, dir)
+		}
+		if err := o.dir.WriteFile(name+overlayTempSuffix, content); err != nil {
+			cleanup()
+			return err
+		}
+		temps = append(temps, o.dir.path(name)+overlayTempSuffix)
+	}
+
+	var errs []error
+	for i, name := range paths {
+		if err := os.Rename(temps[i], o.dir.path(name)); err != nil {
+			os.Remove(temps[i])
+			errs = append(errs, fmt.Errorf("写入 %s 失败: %w", name, err))
+		}
+	}
+	o.pending = NewMemFS()
+	return errors.Join(errs...)
+}
+
+// missingDir 返回文件的上级目录中最外层的不存在的目录，上级目录都已存在时返回空字符串
+func (o *OverlayFS) missingDir(name string) string {
+	missing := ""
+	for dir := path.Dir(name); dir != "." && dir != "/"; dir = path.Dir(dir) {
+		if o.dir.Exists(dir) {
+			break
+		}
+		missing = o.dir.path(dir)
+	}
+	return missing
+}
+
+// Rollback 丢弃尚未提交的写入
+func (o *OverlayFS) Rollback() error {
+	o.pending = NewMemFS()
+	return nil
+}
@@ -0,0 +1,113 @@
+package output
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// Transaction 支持整体提交或回滚的输出目标
+type Transaction interface {
+	// Commit 使全部写入生效
+	Commit() error
+	// Rollback 丢弃全部写入
+	Rollback() error
+}
+
+// StagingFS 先写入目标目录旁边的临时目录，全部成功后再整体重命名为目标目录
+// 临时目录在第一次写入时创建，与目标目录位于同一父目录下以保证重命名是原子的
+type StagingFS struct {
+	target string
+	dir    *DirFS // 临时目录，第一次写入前为 nil
+}
+
+// NewStagingFS 创建以 target 为最终目录的暂存输出
+func NewStagingFS(target string) *StagingFS {
+	return &StagingFS{target: target}
+}
+
+// Target 返回最终目录
+func (s *StagingFS) Target() string {
+	return s.target
+}
+
+// staging 返回临时目录，不存在时创建
+func (s *StagingFS) staging() (*DirFS, error) {
+	if s.dir != nil {
+		return s.dir, nil
+	}
+
+	parent := filepath.Dir(s.target)
+	if err := os.MkdirAll(parent, 0755); err != nil {
+		return nil, err
+	}
+	dir, err := os.MkdirTemp(parent, "."+filepath.Base(s.target)+".archi-gen-*")
+	if err != nil {
+		return nil, err
+	}
+	// MkdirTemp 创建的目录权限为 0700，与普通目录保持一致
+	if err := os.Chmod(dir, 0755); err != nil {
+		os.RemoveAll(dir)
+		return nil, err
+	}
+	s.dir = NewDirFS(dir)
+	return s.dir, nil
+}
+
+// WriteFile 写入文件
+func (s *StagingFS) WriteFile(name string, content []byte) error {
+	dir, err := s.staging()
+	if err != nil {
+		return err
+	}
+	return dir.WriteFile(name, content)
+}
+
+// ReadFile 读取已写入的文件
+func (s *StagingFS) ReadFile(name string) ([]byte, error) {
+	dir, err := s.staging()
+	if err != nil {
+		return nil, err
+	}
+	return dir.ReadFile(name)
+}
+
+// Exists 判断文件或目录是否已写入
+func (s *StagingFS) Exists(name string) bool {
+	return s.dir != nil && s.dir.Exists(name)
+}
+
+// MkdirAll 创建目录
+func (s *StagingFS) MkdirAll(name string) error {
+	dir, err := s.staging()
+	if err != nil {
+		return err
+	}
+	return dir.MkdirAll(name)
+}
+
+// Commit 将临时目录重命名为目标目录，目标目录已存在时失败且不做任何修改
+func (s *StagingFS) Commit() error {
+	dir, err := s.staging()
+	if err != nil {
+		return err
+	}
+	if _, err := os.Lstat(s.target); err == nil {
+		return fmt.Errorf("目录 '%s' 已存在", s.target)
+	}
+	if err := os.Rename(dir.Root(), s.target); err != nil {
+		return err
+	}
+	s.dir = nil
+	return nil
+}
+
+// Rollback 删除临时目录
+func (s *StagingFS) Rollback() error {
+	if s.dir == nil {
+		return nil
+	}
+	err := os.RemoveAll(s.dir.Root())
+	s.dir = nil
+	return err
+}
@@ -0,0 +1,179 @@
+package output
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// writeFiles 写入测试文件，files 为 相对路径 -> 内容
+func writeFiles(t *testing.T, fs FS, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		if err := fs.WriteFile(name, []byte(content)); err != nil {
+			t.Fatalf("WriteFile(%q) error = %v", name, err)
+		}
+	}
+}
+
+// readDir 读取目录中的全部文件，返回 相对路径 -> 内容
+func readDir(t *testing.T, root string) map[string]string {
+	t.Helper()
+	files := make(map[string]string)
+	err := filepath.WalkDir(root, func(path string, d os.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		rel, _ := filepath.Rel(root, path)
+		files[filepath.ToSlash(rel)] = string(content)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return files
+}
+
+// equalFiles 比较两组文件
+func equalFiles(t *testing.T, got, want map[string]string) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Errorf("files = %v, want %v", got, want)
+		return
+	}
+	for name, content := range want {
+		if got[name] != content {
+			t.Errorf("%s = %q, want %q", name, got[name], content)
+		}
+	}
+}
+
+func TestStagingFS(t *testing.T) {
+	files := map[string]string{
+		"go.work":             "go 1.24.11\n",
+		"user/domain/go.mod":  "module example.com/demo/user/domain\n",
+		"cmd/api/main.go":     "package main\n",
+		"configs/config.yaml": "app: demo\n",
+	}
+
+	tests := []struct {
+		name     string
+		existing bool // 目标目录是否已存在
+		commit   bool
+		wantErr  bool
+	}{
+		{name: "提交后生成目标目录", commit: true},
+		{name: "回滚后不留下任何目录"},
+		{name: "目标目录已存在时提交失败", existing: true, commit: true, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parent := t.TempDir()
+			target := filepath.Join(parent, "demo")
+			if tt.existing {
+				if err := os.Mkdir(target, 0755); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			s := NewStagingFS(target)
+			writeFiles(t, s, files)
+			if _, err := os.Stat(filepath.Join(target, "go.work")); err == nil {
+				t.Fatal("提交前不应写入目标目录")
+			}
+			if got, err := s.ReadFile("cmd/api/main.go"); err != nil || string(got) != files["cmd/api/main.go"] {
+				t.Errorf("ReadFile() = %q, %v", got, err)
+			}
+
+			var err error
+			if tt.commit {
+				err = s.Commit()
+			} else {
+				err = s.Rollback()
+			}
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			switch {
+			case tt.wantErr:
+				// 提交失败时已存在的目录保持不变，临时目录由调用方回滚删除
+				if len(readDir(t, target)) != 0 {
+					t.Error("提交失败时不应修改已存在的目录")
+				}
+				if err := s.Rollback(); err != nil {
+					t.Fatal(err)
+				}
+			case tt.commit:
+				equalFiles(t, readDir(t, target), files)
+			}
+
+			// 临时目录不应残留
+			entries, err := os.ReadDir(parent)
+			if err != nil {
+				t.Fatal(err)
+			}
+			want := 0
+			if tt.commit || tt.existing {
+				want = 1
+			}
+			if len(entries) != want {
+				t.Errorf("父目录中有 %d 个条目，want %d", len(entries), want)
+			}
+		})
+	}
+}
+
+func TestOverlayFS(t *testing.T) {
+	existing := map[string]string{
+		"go.work":  "go 1.24.11\n\nuse ./user\n",
+		"Makefile": "build:\n",
+	}
+	changes := map[string]string{
+		"go.work":               "go 1.24.11\n\nuse (\n\t./user\n\t./order\n)\n",
+		"order/domain/go.mod":   "module example.com/demo/order/domain\n",
+		"order/domain/order.go": "package domain\n",
+	}
+
+	for _, commit := range []bool{true, false} {
+		name := "回滚后目录保持不变"
+		if commit {
+			name = "提交后应用全部修改"
+		}
+		t.Run(name, func(t *testing.T) {
+			root := t.TempDir()
+			writeFiles(t, NewDirFS(root), existing)
+
+			o := NewOverlayFS(root)
+			writeFiles(t, o, changes)
+			if got, err := o.ReadFile("go.work"); err != nil || string(got) != changes["go.work"] {
+				t.Errorf("ReadFile() 应返回尚未提交的内容，got %q, %v", got, err)
+			}
+			if got, err := o.ReadFile("Makefile"); err != nil || string(got) != existing["Makefile"] {
+				t.Errorf("ReadFile() 应返回目录中的内容，got %q, %v", got, err)
+			}
+			if !o.Exists("order/domain") || !o.Exists("Makefile") {
+				t.Error("Exists() 应包含目录和尚未提交的写入")
+			}
+			equalFiles(t, readDir(t, root), existing)
+
+			want := existing
+			if commit {
+				if err := o.Commit(); err != nil {
+					t.Fatal(err)
+				}
+				want = map[string]string{"Makefile": existing["Makefile"]}
+				for name, content := range changes {
+					want[name] = content
+				}
+			} else if err := o.Rollback(); err != nil {
+				t.Fatal(err)
+			}
+			equalFiles(t, readDir(t, root), want)
+		})
+	}
+}
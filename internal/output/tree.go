@@ -0,0 +1,101 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+)
+
+// treeNode 文件树节点
+type treeNode struct {
+	name     string
+	size     int
+	files    int // 目录下的文件总数
+	isDir    bool
+	children map[string]*treeNode
+}
+
+// WriteTree 以树形结构输出 MemFS 中的文件及大小
+// collapse 中的目录只显示文件数量和总大小，不展开（例如快照目录）
+func WriteTree(w io.Writer, root string, m *MemFS, collapse ...string) error {
+	tree := &treeNode{name: root, isDir: true, children: map[string]*treeNode{}}
+	for _, name := range m.Paths() {
+		node := tree
+		parts := strings.Split(name, "/")
+		for i, part := range parts {
+			node.size += len(m.files[name])
+			node.files++
+			child, ok := node.children[part]
+			if !ok {
+				child = &treeNode{name: part, isDir: i < len(parts)-1, children: map[string]*treeNode{}}
+				node.children[part] = child
+			}
+			node = child
+		}
+		node.size = len(m.files[name])
+		node.files = 1
+	}
+
+	if _, err := fmt.Fprintf(w, "%s/ (%d 个文件, %s)\n", root, tree.files, FormatSize(tree.size)); err != nil {
+		return err
+	}
+	return writeChildren(w, tree, "", "", collapse)
+}
+
+// writeChildren 递归输出子节点，目录在前、文件在后，各自按名称排序
+func writeChildren(w io.Writer, node *treeNode, prefix, dir string, collapse []string) error {
+	children := make([]*treeNode, 0, len(node.children))
+	for _, child := range node.children {
+		children = append(children, child)
+	}
+	slices.SortFunc(children, func(a, b *treeNode) int {
+		if a.isDir != b.isDir {
+			if a.isDir {
+				return -1
+			}
+			return 1
+		}
+		return strings.Compare(a.name, b.name)
+	})
+
+	for i, child := range children {
+		branch, indent := "├── ", "│   "
+		if i == len(children)-1 {
+			branch, indent = "└── ", "    "
+		}
+		childPath := strings.TrimPrefix(dir+"/"+child.name, "/")
+
+		if !child.isDir {
+			if _, err := fmt.Fprintf(w, "%s%s%s (%s)\n", prefix, branch, child.name, FormatSize(child.size)); err != nil {
+				return err
+			}
+			continue
+		}
+		if slices.Contains(collapse, childPath) {
+			if _, err := fmt.Fprintf(w, "%s%s%s/ (%d 个文件, %s)\n", prefix, branch, child.name, child.files, FormatSize(child.size)); err != nil {
+				return err
+			}
+			continue
+		}
+		if _, err := fmt.Fprintf(w, "%s%s%s/\n", prefix, branch, child.name); err != nil {
+			return err
+		}
+		if err := writeChildren(w, child, prefix+indent, childPath, collapse); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// FormatSize 格式化文件大小
+func FormatSize(size int) string {
+	switch {
+	case size < 1024:
+		return fmt.Sprintf("%d B", size)
+	case size < 1024*1024:
+		return fmt.Sprintf("%.1f KB", float64(size)/1024)
+	default:
+		return fmt.Sprintf("%.1f MB", float64(size)/1024/1024)
+	}
+}
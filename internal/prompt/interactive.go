@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
-	"regexp"
 	"strings"
 
 	"github.com/AlecAivazis/survey/v2"
@@ -19,70 +18,104 @@ func NewInteractive() *Interactive {
 	return &Interactive{}
 }
 
-// AskProjectConfig 询问项目配置
-func (i *Interactive) AskProjectConfig() (*config.ProjectConfig, error) {
+// AskProjectConfig 询问项目配置，preset 中已设置的字段不再询问
+func (i *Interactive) AskProjectConfig(preset *config.Options) (*config.ProjectConfig, error) {
 	cfg := config.NewProjectConfig()
+	if preset == nil {
+		preset = &config.Options{}
+	}
+	if err := preset.Apply(cfg); err != nil {
+		return nil, err
+	}
 
 	// 1. 询问项目名称
-	projectName, err := i.askProjectName()
-	if err != nil {
-		return nil, err
+	if preset.ProjectName == "" {
+		projectName, err := i.askProjectName()
+		if err != nil {
+			return nil, err
+		}
+		cfg.ProjectName = projectName
 	}
-	cfg.ProjectName = projectName
 
 	// 2. 询问开发语言
-	language, err := i.askLanguage()
-	if err != nil {
-		return nil, err
+	if preset.Language == "" {
+		language, err := i.askLanguage()
+		if err != nil {
+			return nil, err
+		}
+		cfg.Language = language
 	}
-	cfg.Language = language
 
-	// 3. 询问是否托管到远程仓库
-	isHosted, err := i.askIsHosted()
-	if err != nil {
-		return nil, err
+	if preset.ModulePath == "" {
+		// 3. 询问是否托管到远程仓库
+		isHosted, err := i.askIsHosted()
+		if err != nil {
+			return nil, err
+		}
+
+		// 4. 根据是否托管决定模块路径
+		var modulePath string
+		if isHosted {
+			// 托管到远程仓库，需要输入完整模块路径
+			modulePath, err = i.askModulePath(cfg.ProjectName)
+			if err != nil {
+				return nil, err
+			}
+		} else {
+			// 不托管，使用项目名称作为模块路径
+			modulePath = cfg.ProjectName
+		}
+		cfg.ModulePath = modulePath
 	}
 
-	// 4. 根据是否托管决定模块路径
-	var modulePath string
-	if isHosted {
-		// 托管到远程仓库，需要输入完整模块路径
-		modulePath, err = i.askModulePath(projectName)
+	// 5. 询问数据库
+	if preset.Database == "" {
+		database, err := i.askDatabase()
 		if err != nil {
 			return nil, err
 		}
-	} else {
-		// 不托管，使用项目名称作为模块路径
-		modulePath = projectName
+		cfg.Database = database
 	}
-	cfg.ModulePath = modulePath
 
-	// 5. 询问是否使用 Redis
-	useRedis, err := i.askUseRedis()
-	if err != nil {
-		return nil, err
+	// 6. 询问 HTTP 框架
+	if preset.Framework == "" {
+		framework, err := i.askFramework()
+		if err != nil {
+			return nil, err
+		}
+		cfg.Framework = framework
 	}
-	cfg.UseRedis = useRedis
 
-	// 6. 询问是否自定义输出路径
-	customOutputPath, err := i.askCustomOutputPath()
-	if err != nil {
-		return nil, err
+	// 7. 询问是否使用 Redis
+	if preset.UseRedis == nil {
+		useRedis, err := i.askUseRedis()
+		if err != nil {
+			return nil, err
+		}
+		cfg.UseRedis = useRedis
 	}
 
-	// 7. 根据是否自定义决定输出路径
-	var outputPath string
-	if customOutputPath {
-		// 用户自定义路径
-		outputPath, err = i.askOutputPath()
+	if preset.OutputPath == "" {
+		// 8. 询问是否自定义输出路径
+		customOutputPath, err := i.askCustomOutputPath()
 		if err != nil {
 			return nil, err
 		}
-	} else {
-		// 使用默认路径（当前目录）
-		outputPath = i.getDefaultOutputPath()
+
+		// 9. 根据是否自定义决定输出路径
+		var outputPath string
+		if customOutputPath {
+			// 用户自定义路径
+			outputPath, err = i.askOutputPath()
+			if err != nil {
+				return nil, err
+			}
+		} else {
+			// 使用默认路径（当前目录）
+			outputPath = i.getDefaultOutputPath()
+		}
+		cfg.OutputPath = outputPath
 	}
-	cfg.OutputPath = outputPath
 
 	return cfg, nil
 }
@@ -98,13 +131,7 @@ func (i *Interactive) askProjectName() (string, error) {
 	validator := survey.ComposeValidators(
 		survey.Required,
 		func(val interface{}) error {
-			str := val.(string)
-			// 验证项目名称格式（只允许字母、数字、下划线、中划线）
-			matched, _ := regexp.MatchString(`^[a-zA-Z][a-zA-Z0-9_-]*$`, str)
-			if !matched {
-				return fmt.Errorf("项目名称必须以字母开头，只能包含字母、数字、下划线和中划线")
-			}
-			return nil
+			return config.ValidateProjectName(val.(string))
 		},
 	)
 
@@ -172,9 +199,9 @@ func (i *Interactive) askModulePath(projectName string) (string, error) {
 			str := val.(string)
 			// 简单验证模块路径格式
 			if !strings.Contains(str, "/") {
-				return fmt.Errorf("模块路径格式不正确，应类似: github.com/username/project")
+				return config.ErrModulePathInvalid
 			}
-			return nil
+			return config.ValidateModulePath(str)
 		},
 	)
 
@@ -186,6 +213,52 @@ func (i *Interactive) askModulePath(projectName string) (string, error) {
 	return modulePath, nil
 }
 
+// askDatabase 询问数据库
+func (i *Interactive) askDatabase() (string, error) {
+	options := make([]string, 0, len(config.Databases))
+	for _, database := range config.Databases {
+		options = append(options, config.DatabaseName(database))
+	}
+
+	var index int
+	prompt := &survey.Select{
+		Message: "请选择数据库:",
+		Options: options,
+		Default: options[0],
+		Help:    "影响数据库驱动、docker-compose 中的数据库服务以及表字段类型",
+	}
+
+	err := survey.AskOne(prompt, &index)
+	if err != nil {
+		return "", err
+	}
+
+	return config.Databases[index], nil
+}
+
+// askFramework 询问 HTTP 框架
+func (i *Interactive) askFramework() (string, error) {
+	options := make([]string, 0, len(config.Frameworks))
+	for _, framework := range config.Frameworks {
+		options = append(options, config.FrameworkName(framework))
+	}
+
+	var index int
+	prompt := &survey.Select{
+		Message: "请选择 HTTP 框架:",
+		Options: options,
+		Default: options[0],
+		Help:    "影响 HTTP 处理器、错误处理和 cmd/api 入口，应用服务与框架无关",
+	}
+
+	err := survey.AskOne(prompt, &index)
+	if err != nil {
+		return "", err
+	}
+
+	return config.Frameworks[index], nil
+}
+
 // askUseRedis 询问是否使用 Redis
 func (i *Interactive) askUseRedis() (bool, error) {
 	var useRedis bool
@@ -0,0 +1,206 @@
+package spec
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// entityNamePattern 实体名称格式
+var entityNamePattern = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9_-]*$`)
+
+// reservedFields 由审计字段占用的字段名
+var reservedFields = map[string]bool{
+	"created_at": true,
+	"updated_at": true,
+	"deleted_at": true,
+	"version":    true,
+}
+
+// Entity 实体（聚合根）定义
+//
+// YAML 格式:
+//
+//	name: Order
+//	fields:
+//	  - id:uuid
+//	  - total:decimal:required
+//	  - name: status
+//	    type: enum
+//	    values: [pending, paid, cancelled]
+type Entity struct {
+	Name   string  `yaml:"name"`   // 实体名称 (例如: Order)
+	Fields []Field `yaml:"fields"` // 字段列表 (可包含 id 主键)
+
+	// TypePrefix 生成类型名时使用的前缀 (例如: Order -> OrderStatus)，由模板上下文填充
+	TypePrefix string `yaml:"-"`
+}
+
+// NewEntity 根据名称和字段创建实体定义并校验
+func NewEntity(name string, fields []Field) (*Entity, error) {
+	e := &Entity{Name: name, Fields: fields}
+	if err := e.Validate(); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// DefaultEntity 返回 add aggregate 使用的默认实体定义
+func DefaultEntity(name string) *Entity {
+	return &Entity{
+		Name: name,
+		Fields: []Field{
+			{Name: "id", Type: TypeUUID},
+			{Name: "name", Type: TypeString, Size: 100, Required: true, Unique: true},
+			{Name: "status", Type: TypeEnum, Values: []string{"inactive", "active", "disabled"}},
+		},
+	}
+}
+
+// LoadFile 从 YAML 文件加载实体定义
+func LoadFile(path string) (*Entity, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var e Entity
+	if err := yaml.Unmarshal(content, &e); err != nil {
+		return nil, fmt.Errorf("解析实体定义文件 '%s' 失败: %w", path, err)
+	}
+	if err := e.Validate(); err != nil {
+		return nil, err
+	}
+	return &e, nil
+}
+
+// Validate 校验实体定义
+func (e *Entity) Validate() error {
+	if !entityNamePattern.MatchString(e.Name) {
+		return fmt.Errorf("实体名称 '%s' 无效: 必须以字母开头，只能包含字母、数字、下划线和中划线", e.Name)
+	}
+
+	seen := make(map[string]bool, len(e.Fields))
+	hasBusinessField := false
+	for _, f := range e.Fields {
+		if err := f.Validate(); err != nil {
+			return err
+		}
+		if seen[f.Name] {
+			return fmt.Errorf("字段 '%s' 重复定义", f.Name)
+		}
+		seen[f.Name] = true
+		if reservedFields[f.Name] {
+			return fmt.Errorf("字段 '%s' 为审计字段，会自动生成，请勿重复定义", f.Name)
+		}
+		if f.Name == "id" {
+			if f.Type != TypeUUID && f.Type != TypeInt64 {
+				return fmt.Errorf("主键 id 只支持 uuid 或 int64 类型，当前为 '%s'", f.Type)
+			}
+			continue
+		}
+		hasBusinessField = true
+	}
+	if !hasBusinessField {
+		return fmt.Errorf("实体 '%s' 至少需要一个业务字段", e.Name)
+	}
+	return nil
+}
+
+// ID 返回主键字段，未定义时默认为 uuid
+func (e *Entity) ID() Field {
+	for _, f := range e.Fields {
+		if f.Name == "id" {
+			return f
+		}
+	}
+	return Field{Name: "id", Type: TypeUUID}
+}
+
+// BusinessFields 返回除主键外的业务字段，并填充模板所需的派生信息
+func (e *Entity) BusinessFields() []Field {
+	prefix := e.TypePrefix
+	if prefix == "" {
+		prefix = goIdentifier(strings.ReplaceAll(e.Name, "-", "_"))
+	}
+	fields := make([]Field, 0, len(e.Fields))
+	for _, f := range e.Fields {
+		if f.Name == "id" {
+			continue
+		}
+		if f.Type == TypeEnum {
+			f.EnumType = prefix + f.GoName()
+		}
+		fields = append(fields, f)
+	}
+	return fields
+}
+
+// UniqueFields 返回唯一字段
+func (e *Entity) UniqueFields() []Field {
+	var fields []Field
+	for _, f := range e.BusinessFields() {
+		if f.Unique {
+			fields = append(fields, f)
+		}
+	}
+	return fields
+}
+
+// EnumFields 返回枚举字段
+func (e *Entity) EnumFields() []Field {
+	var fields []Field
+	for _, f := range e.BusinessFields() {
+		if f.IsEnum() {
+			fields = append(fields, f)
+		}
+	}
+	return fields
+}
+
+// HasOptionalEnum 是否存在非必填的枚举字段（创建时需要填充默认值）
+func (e *Entity) HasOptionalEnum() bool {
+	for _, f := range e.EnumFields() {
+		if !f.Required {
+			return true
+		}
+	}
+	return false
+}
+
+// UsesType 判断业务字段中是否使用了指定类型（用于决定 import）
+func (e *Entity) UsesType(t FieldType) bool {
+	for _, f := range e.BusinessFields() {
+		if f.Type == t {
+			return true
+		}
+	}
+	return false
+}
+
+// UniqueUsesType 判断唯一字段中是否使用了指定类型（用于决定仓储接口的 import）
+func (e *Entity) UniqueUsesType(t FieldType) bool {
+	for _, f := range e.UniqueFields() {
+		if f.Type == t {
+			return true
+		}
+	}
+	return false
+}
+
+// IsUUIDKey 主键是否为 uuid
+func (e *Entity) IsUUIDKey() bool {
+	return e.ID().Type == TypeUUID
+}
+
+// FieldSpecs 返回命令行格式的字段定义列表
+func (e *Entity) FieldSpecs() []string {
+	specs := make([]string, len(e.Fields))
+	for i, f := range e.Fields {
+		specs[i] = f.String()
+	}
+	return specs
+}
@@ -0,0 +1,507 @@
+package spec
+
+import (
+	"fmt"
+	"go/token"
+	"go/types"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/tuza/scaffolding-code-generation/internal/config"
+	"gopkg.in/yaml.v3"
+)
+
+// FieldType 字段类型
+type FieldType string
+
+const (
+	TypeString  FieldType = "string"  // 字符串 (varchar)
+	TypeText    FieldType = "text"    // 长文本
+	TypeInt     FieldType = "int"     // 整数
+	TypeInt64   FieldType = "int64"   // 长整数
+	TypeFloat   FieldType = "float"   // 浮点数
+	TypeDecimal FieldType = "decimal" // 定点小数 (shopspring/decimal)
+	TypeBool    FieldType = "bool"    // 布尔值
+	TypeUUID    FieldType = "uuid"    // UUID
+	TypeTime    FieldType = "time"    // 时间
+	TypeEnum    FieldType = "enum"    // 枚举 (字符串取值)
+)
+
+// supportedTypes 支持的字段类型
+var supportedTypes = map[FieldType]bool{
+	TypeString:  true,
+	TypeText:    true,
+	TypeInt:     true,
+	TypeInt64:   true,
+	TypeFloat:   true,
+	TypeDecimal: true,
+	TypeBool:    true,
+	TypeUUID:    true,
+	TypeTime:    true,
+	TypeEnum:    true,
+}
+
+// uniqueTypes 支持 unique 修饰符的字段类型（会生成 FindByXxx/ExistsByXxx 方法）
+var uniqueTypes = map[FieldType]bool{
+	TypeString: true,
+	TypeInt:    true,
+	TypeInt64:  true,
+	TypeUUID:   true,
+}
+
+// defaultStringSize 字符串字段默认长度
+const defaultStringSize = 255
+
+var (
+	fieldNamePattern = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+	enumValuePattern = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9_-]*$`)
+	typeArgsPattern  = regexp.MustCompile(`^([a-z0-9]+)(?:\((.*)\))?$`)
+)
+
+// Field 实体字段定义
+//
+// 命令行格式: name:type[:modifier...]
+//
+//	total:decimal:required
+//	status:enum(pending,paid,cancelled)
+//	email:string(100):required:unique
+type Field struct {
+	Name     string    `yaml:"name"`               // 字段名 (snake_case)
+	Type     FieldType `yaml:"type"`               // 字段类型
+	Values   []string  `yaml:"values,omitempty"`   // 枚举取值 (仅 enum)
+	Size     int       `yaml:"size,omitempty"`     // 长度 (仅 string)
+	Required bool      `yaml:"required,omitempty"` // 是否必填
+	Unique   bool      `yaml:"unique,omitempty"`   // 是否唯一
+	Index    bool      `yaml:"index,omitempty"`    // 是否建立索引
+
+	// 由 Entity 填充，供模板使用
+	EnumType string `yaml:"-"` // 枚举类型名 (例如: OrderStatus)
+}
+
+// ParseField 解析命令行字段定义
+func ParseField(s string) (Field, error) {
+	parts := strings.Split(s, ":")
+	if len(parts) < 2 {
+		return Field{}, fmt.Errorf("字段定义 '%s' 格式错误，应为 name:type[:modifier...]", s)
+	}
+
+	field := Field{Name: parts[0]}
+
+	matches := typeArgsPattern.FindStringSubmatch(parts[1])
+	if matches == nil {
+		return Field{}, fmt.Errorf("字段 '%s' 的类型 '%s' 格式错误", field.Name, parts[1])
+	}
+	field.Type = FieldType(matches[1])
+	if args := matches[2]; args != "" {
+		switch field.Type {
+		case TypeEnum:
+			for _, v := range strings.Split(args, ",") {
+				field.Values = append(field.Values, strings.TrimSpace(v))
+			}
+		case TypeString:
+			size, err := strconv.Atoi(args)
+			if err != nil {
+				return Field{}, fmt.Errorf("字段 '%s' 的长度 '%s' 不是整数", field.Name, args)
+			}
+			if size <= 0 {
+				return Field{}, fmt.Errorf("字段 '%s' 的长度必须大于 0，当前为 %d", field.Name, size)
+			}
+			field.Size = size
+		default:
+			return Field{}, fmt.Errorf("字段 '%s' 的类型 '%s' 不支持参数", field.Name, field.Type)
+		}
+	}
+
+	for _, modifier := range parts[2:] {
+		switch modifier {
+		case "required":
+			field.Required = true
+		case "unique":
+			field.Unique = true
+		case "index":
+			field.Index = true
+		default:
+			return Field{}, fmt.Errorf("字段 '%s' 的修饰符 '%s' 不支持（可选: required, unique, index）", field.Name, modifier)
+		}
+	}
+
+	return field, field.Validate()
+}
+
+// ParseFields 批量解析命令行字段定义
+func ParseFields(args []string) ([]Field, error) {
+	fields := make([]Field, 0, len(args))
+	for _, arg := range args {
+		field, err := ParseField(arg)
+		if err != nil {
+			return nil, err
+		}
+		fields = append(fields, field)
+	}
+	return fields, nil
+}
+
+// UnmarshalYAML 支持在 YAML 中使用命令行格式的字符串定义字段
+func (f *Field) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		field, err := ParseField(node.Value)
+		if err != nil {
+			return err
+		}
+		*f = field
+		return nil
+	}
+
+	type plain Field
+	var p plain
+	if err := node.Decode(&p); err != nil {
+		return err
+	}
+	*f = Field(p)
+	return f.Validate()
+}
+
+// String 返回命令行格式的字段定义
+func (f Field) String() string {
+	var b strings.Builder
+	b.WriteString(f.Name)
+	b.WriteString(":")
+	b.WriteString(string(f.Type))
+	switch {
+	case f.Type == TypeEnum:
+		b.WriteString("(" + strings.Join(f.Values, ",") + ")")
+	case f.Type == TypeString && f.Size > 0:
+		b.WriteString("(" + strconv.Itoa(f.Size) + ")")
+	}
+	if f.Required {
+		b.WriteString(":required")
+	}
+	if f.Unique {
+		b.WriteString(":unique")
+	}
+	if f.Index {
+		b.WriteString(":index")
+	}
+	return b.String()
+}
+
+// Validate 校验字段定义
+func (f Field) Validate() error {
+	if !fieldNamePattern.MatchString(f.Name) {
+		return fmt.Errorf("字段名 '%s' 无效: 必须为小写字母开头的 snake_case", f.Name)
+	}
+	if !supportedTypes[f.Type] {
+		return fmt.Errorf("字段 '%s' 的类型 '%s' 不支持", f.Name, f.Type)
+	}
+	if f.Size < 0 {
+		return fmt.Errorf("字段 '%s' 的长度必须大于 0，当前为 %d", f.Name, f.Size)
+	}
+	if f.Type == TypeEnum {
+		if len(f.Values) == 0 {
+			return fmt.Errorf("枚举字段 '%s' 至少需要一个取值，例如 %s:enum(a,b)", f.Name, f.Name)
+		}
+		seen := make(map[string]bool, len(f.Values))
+		for _, v := range f.Values {
+			if !enumValuePattern.MatchString(v) {
+				return fmt.Errorf("枚举字段 '%s' 的取值 '%s' 无效", f.Name, v)
+			}
+			if seen[v] {
+				return fmt.Errorf("枚举字段 '%s' 的取值 '%s' 重复", f.Name, v)
+			}
+			seen[v] = true
+		}
+	} else if len(f.Values) > 0 {
+		return fmt.Errorf("字段 '%s' 不是枚举类型，不能指定取值", f.Name)
+	}
+	if f.Unique && !uniqueTypes[f.Type] {
+		return fmt.Errorf("字段 '%s' 的类型 '%s' 不支持 unique（可选: string, int, int64, uuid）", f.Name, f.Type)
+	}
+	return nil
+}
+
+// GoName 返回 Go 字段名 (例如: user_id -> UserID)
+func (f Field) GoName() string {
+	return goIdentifier(f.Name)
+}
+
+// CamelName 返回 camelCase 名称，用于局部变量
+// 名称为 Go 关键字、预声明标识符或生成代码中的局部变量时追加 Value 后缀 (例如: type -> typeValue)
+func (f Field) CamelName() string {
+	first, rest, _ := strings.Cut(f.Name, "_")
+	name := first + goIdentifier(rest)
+	if token.IsKeyword(name) || types.Universe.Lookup(name) != nil || localIdentifiers[name] {
+		name += "Value"
+	}
+	return name
+}
+
+// localIdentifiers 生成的方法中已使用的参数名，字段的局部变量名不能与之相同
+var localIdentifiers = map[string]bool{
+	"ctx": true,
+}
+
+// Column 返回数据库列名
+func (f Field) Column() string {
+	return f.Name
+}
+
+// IsEnum 是否枚举类型
+func (f Field) IsEnum() bool {
+	return f.Type == TypeEnum
+}
+
+// EnumConsts 返回枚举常量 (Go 常量名 -> 取值)
+func (f Field) EnumConsts() []EnumConst {
+	consts := make([]EnumConst, len(f.Values))
+	for i, v := range f.Values {
+		consts[i] = EnumConst{Name: f.EnumType + goIdentifier(strings.ReplaceAll(v, "-", "_")), Value: v}
+	}
+	return consts
+}
+
+// EnumConst 枚举常量
+type EnumConst struct {
+	Name  string // Go 常量名 (例如: OrderStatusPending)
+	Value string // 取值 (例如: pending)
+}
+
+// DomainType 返回领域实体中的 Go 类型
+func (f Field) DomainType() string {
+	if f.Type == TypeEnum {
+		return "enum." + f.EnumType
+	}
+	return f.goType()
+}
+
+// POType 返回持久化对象中的 Go 类型
+func (f Field) POType() string {
+	if f.Type == TypeEnum {
+		return "string"
+	}
+	return f.goType()
+}
+
+// DTOType 返回请求/响应 DTO 中的 Go 类型
+func (f Field) DTOType() string {
+	return f.POType()
+}
+
+// goType 基础类型映射
+func (f Field) goType() string {
+	switch f.Type {
+	case TypeString, TypeText:
+		return "string"
+	case TypeInt:
+		return "int"
+	case TypeInt64:
+		return "int64"
+	case TypeFloat:
+		return "float64"
+	case TypeDecimal:
+		return "decimal.Decimal"
+	case TypeBool:
+		return "bool"
+	case TypeUUID:
+		return "uuid.UUID"
+	case TypeTime:
+		return "time.Time"
+	default:
+		return "string"
+	}
+}
+
+// ToPOExpr 返回领域值转换为 PO 值的表达式
+func (f Field) ToPOExpr(expr string) string {
+	if f.Type == TypeEnum {
+		return "string(" + expr + ")"
+	}
+	return expr
+}
+
+// ToDomainExpr 返回 PO/DTO 值转换为领域值的表达式
+func (f Field) ToDomainExpr(expr string) string {
+	if f.Type == TypeEnum {
+		return "enum." + f.EnumType + "(" + expr + ")"
+	}
+	return expr
+}
+
+// ColumnType 返回字段在指定数据库（postgres、mysql、sqlite）中的列类型
+func (f Field) ColumnType(database string) string {
+	switch f.Type {
+	case TypeString:
+		size := f.Size
+		if size <= 0 {
+			size = defaultStringSize
+		}
+		return fmt.Sprintf("varchar(%d)", size)
+	case TypeEnum:
+		return "varchar(32)"
+	case TypeText:
+		return "text"
+	}
+
+	switch database {
+	case config.DatabaseMySQL:
+		return mysqlColumnTypes[f.Type]
+	case config.DatabaseSQLite:
+		return sqliteColumnTypes[f.Type]
+	default:
+		return postgresColumnTypes[f.Type]
+	}
+}
+
+// 各数据库的列类型（string、enum、text 在三种数据库中相同，不在此列出）
+var (
+	postgresColumnTypes = map[FieldType]string{
+		TypeInt:     "int",
+		TypeInt64:   "bigint",
+		TypeFloat:   "double precision",
+		TypeDecimal: "decimal(20,4)",
+		TypeBool:    "boolean",
+		TypeUUID:    "uuid",
+		TypeTime:    "timestamp",
+	}
+	mysqlColumnTypes = map[FieldType]string{
+		TypeInt:     "int",
+		TypeInt64:   "bigint",
+		TypeFloat:   "double",
+		TypeDecimal: "decimal(20,4)",
+		TypeBool:    "tinyint(1)",
+		TypeUUID:    "char(36)",
+		TypeTime:    "datetime(3)",
+	}
+	// SQLite 的 decimal 以文本保存，避免按数值亲和性转换为浮点数丢失精度
+	sqliteColumnTypes = map[FieldType]string{
+		TypeInt:     "integer",
+		TypeInt64:   "integer",
+		TypeFloat:   "real",
+		TypeDecimal: "text",
+		TypeBool:    "boolean",
+		TypeUUID:    "text",
+		TypeTime:    "datetime",
+	}
+)
+
+// GormTag 返回 PO 字段在指定数据库中的 gorm 标签内容
+func (f Field) GormTag(database string) string {
+	parts := []string{"column:" + f.Column(), "type:" + f.ColumnType(database)}
+	switch {
+	case f.Unique:
+		parts = append(parts, "uniqueIndex")
+	case f.Index:
+		parts = append(parts, "index")
+	}
+	if f.Required {
+		parts = append(parts, "not null")
+	}
+	if f.Type == TypeEnum {
+		parts = append(parts, "default:'"+f.Values[0]+"'")
+	}
+	return strings.Join(parts, ";")
+}
+
+// VDTag 返回请求 DTO 的 Hertz vd 校验表达式，无需校验时返回空字符串
+func (f Field) VDTag() string {
+	var exprs []string
+	switch f.Type {
+	case TypeString, TypeText:
+		if f.Required {
+			exprs = append(exprs, "len($)>0")
+		}
+		if f.Type == TypeString {
+			size := f.Size
+			if size <= 0 {
+				size = defaultStringSize
+			}
+			exprs = append(exprs, fmt.Sprintf("len($)<=%d", size))
+		}
+	case TypeEnum:
+		quoted := make([]string, len(f.Values))
+		for i, v := range f.Values {
+			quoted[i] = "'" + v + "'"
+		}
+		expr := "in($," + strings.Join(quoted, ",") + ")"
+		if !f.Required {
+			expr = "$=='' || " + expr
+		}
+		exprs = append(exprs, expr)
+	}
+	return strings.Join(exprs, " && ")
+}
+
+// UpdateVDTag 返回更新请求（指针字段）的 vd 校验表达式，字段未传时跳过校验
+func (f Field) UpdateVDTag() string {
+	// 更新时传入的枚举值必须有效，不允许置空
+	if f.Type == TypeEnum {
+		f.Required = true
+	}
+	expr := f.VDTag()
+	if expr == "" {
+		return ""
+	}
+	return "$==nil || (" + expr + ")"
+}
+
+// ValidateTag 返回请求 DTO 的 validator 校验规则（Gin 的 binding 标签、chi 的 validate 标签），无需校验时返回空字符串
+// 校验语义与 VDTag 一致
+func (f Field) ValidateTag() string {
+	rules := f.validateRules()
+	switch {
+	case f.Required && (f.Type == TypeString || f.Type == TypeText || f.Type == TypeEnum):
+		rules = append([]string{"required"}, rules...)
+	case f.Type == TypeEnum:
+		rules = append([]string{"omitempty"}, rules...)
+	}
+	return strings.Join(rules, ",")
+}
+
+// UpdateValidateTag 返回更新请求（指针字段）的 validator 校验规则，字段未传时跳过校验
+func (f Field) UpdateValidateTag() string {
+	rules := f.validateRules()
+	if f.Required && (f.Type == TypeString || f.Type == TypeText) {
+		rules = append([]string{"min=1"}, rules...)
+	}
+	if len(rules) == 0 {
+		return ""
+	}
+	return "omitempty," + strings.Join(rules, ",")
+}
+
+// validateRules 返回与是否必填无关的 validator 校验规则
+func (f Field) validateRules() []string {
+	switch f.Type {
+	case TypeString:
+		size := f.Size
+		if size <= 0 {
+			size = defaultStringSize
+		}
+		return []string{fmt.Sprintf("max=%d", size)}
+	case TypeEnum:
+		return []string{"oneof=" + strings.Join(f.Values, " ")}
+	}
+	return nil
+}
+
+// initialisms Go 常见缩写词
+var initialisms = map[string]bool{
+	"id": true, "url": true, "uri": true, "ip": true, "api": true, "uuid": true,
+	"http": true, "https": true, "json": true, "xml": true, "sql": true, "html": true,
+}
+
+// goIdentifier snake_case 转换为导出的 Go 标识符，处理常见缩写
+func goIdentifier(name string) string {
+	var b strings.Builder
+	for _, word := range strings.Split(name, "_") {
+		if word == "" {
+			continue
+		}
+		if initialisms[word] {
+			b.WriteString(strings.ToUpper(word))
+			continue
+		}
+		b.WriteString(strings.ToUpper(word[:1]) + word[1:])
+	}
+	return b.String()
+}
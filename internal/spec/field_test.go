@@ -0,0 +1,100 @@
+package spec
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestParseField(t *testing.T) {
+	tests := []struct {
+		spec    string
+		want    Field
+		wantErr string // 错误信息中应包含的内容，为空表示应解析成功
+	}{
+		{spec: "name:string", want: Field{Name: "name", Type: TypeString}},
+		{spec: "name:string(64)", want: Field{Name: "name", Type: TypeString, Size: 64}},
+		{spec: "email:string(100):required:unique", want: Field{Name: "email", Type: TypeString, Size: 100, Required: true, Unique: true}},
+		{spec: "seq:int64:index", want: Field{Name: "seq", Type: TypeInt64, Index: true}},
+		{spec: "status:enum(open, closed)", want: Field{Name: "status", Type: TypeEnum, Values: []string{"open", "closed"}}},
+		{spec: "type:string", want: Field{Name: "type", Type: TypeString}},
+		{spec: "name:string(0)", wantErr: "长度必须大于 0"},
+		{spec: "name:string(-5)", wantErr: "长度必须大于 0"},
+		{spec: "name:string(abc)", wantErr: "不是整数"},
+		{spec: "qty:int(10)", wantErr: "不支持参数"},
+		{spec: "name:varchar", wantErr: "不支持"},
+		{spec: "name:String", wantErr: "格式错误"},
+		{spec: "name", wantErr: "格式错误"},
+		{spec: "Name:string", wantErr: "字段名"},
+		{spec: "name:string:primary", wantErr: "修饰符"},
+		{spec: "status:enum", wantErr: "至少需要一个取值"},
+		{spec: "status:enum(a,a)", wantErr: "重复"},
+		{spec: "bio:text:unique", wantErr: "不支持 unique"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			got, err := ParseField(tt.spec)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("ParseField(%q) error = %v, want error containing %q", tt.spec, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseField(%q) error = %v", tt.spec, err)
+			}
+			if got.Name != tt.want.Name || got.Type != tt.want.Type || got.Size != tt.want.Size ||
+				got.Required != tt.want.Required || got.Unique != tt.want.Unique || got.Index != tt.want.Index ||
+				!slices.Equal(got.Values, tt.want.Values) {
+				t.Errorf("ParseField(%q) = %+v, want %+v", tt.spec, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFieldNames(t *testing.T) {
+	tests := []struct {
+		name      string
+		goName    string
+		camelName string
+	}{
+		{"name", "Name", "name"},
+		{"user_id", "UserID", "userID"},
+		{"created_at", "CreatedAt", "createdAt"},
+		// Go 关键字、预声明标识符和生成代码中的参数名追加 Value 后缀
+		{"type", "Type", "typeValue"},
+		{"range", "Range", "rangeValue"},
+		{"func", "Func", "funcValue"},
+		{"string", "String", "stringValue"},
+		{"len", "Len", "lenValue"},
+		{"ctx", "Ctx", "ctxValue"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := Field{Name: tt.name, Type: TypeString}
+			if got := f.GoName(); got != tt.goName {
+				t.Errorf("GoName() = %q, want %q", got, tt.goName)
+			}
+			if got := f.CamelName(); got != tt.camelName {
+				t.Errorf("CamelName() = %q, want %q", got, tt.camelName)
+			}
+		})
+	}
+}
+
+func TestFieldString(t *testing.T) {
+	for _, spec := range []string{
+		"name:string",
+		"email:string(100):required:unique",
+		"status:enum(open,closed):required",
+		"seq:int64:index",
+	} {
+		f, err := ParseField(spec)
+		if err != nil {
+			t.Fatalf("ParseField(%q) error = %v", spec, err)
+		}
+		if got := f.String(); got != spec {
+			t.Errorf("String() = %q, want %q", got, spec)
+		}
+	}
+}
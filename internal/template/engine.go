@@ -1,65 +1,120 @@
 package template
 
 import (
-	"bytes"
 	"strings"
 	"text/template"
 
 	"github.com/tuza/scaffolding-code-generation/internal/config"
+	"github.com/tuza/scaffolding-code-generation/internal/spec"
 )
 
 // Context 模板上下文
 type Context struct {
-	ProjectName  string // 项目名称
-	ModulePath   string // 模块路径
-	UseRedis     bool   // 是否使用 Redis
-	Database     string // 数据库类型 (固定为 postgres)
-	DBDriver     string // 数据库驱动
-	DBDSNExample string // DSN 示例
+	ProjectName    string   // 项目名称
+	ModulePath     string   // 模块路径
+	UseRedis       bool     // 是否使用 Redis
+	Database       string   // 数据库类型 (postgres, mysql, sqlite)
+	DatabaseName   string   // 数据库显示名称 (例如: PostgreSQL)
+	DBDriver       string   // GORM 数据库驱动模块
+	DBType         string   // share/repository/gorm 中的 DatabaseType 常量名 (例如: PostgreSQL)
+	DBPort         int      // 数据库默认端口，SQLite 为 0
+	DBDSNExample   string   // DSN 示例
+	Framework      string   // HTTP 框架 (hertz, gin, chi)，框架专属模板位于 FrameworkTree 返回的模板树中
+	FrameworkName  string   // HTTP 框架显示名称 (例如: Gin)
+	ValidateTagKey string   // 请求 DTO 校验标签名：Hertz 为 vd，Gin 为 binding，chi 为 validate
+	QueryTagKey    string   // 请求 DTO 查询参数标签名：Gin 为 form，其余为 query
+	Modules        []string // Go 模块目录，按依赖顺序排列（被依赖的模块在前）
+	BOMImports     []string // bom/bom.go 中空导入的包，与 bom/go.mod 中的依赖对应
+
+	// 聚合相关（add aggregate 时使用）
+	Aggregate       string       // 聚合名称，snake_case (例如: order_item)
+	AggregatePascal string       // 聚合名称，PascalCase (例如: OrderItem)
+	AggregateCamel  string       // 聚合名称，camelCase (例如: orderItem)
+	AggregateKebab  string       // 聚合名称，kebab-case (例如: order-item)
+	AggregatePlural string       // 复数形式，用于表名 (例如: order_items)
+	ErrorCodeBase   int          // 错误码分段起始值 (例如: 12000)
+	Entity          *spec.Entity // 聚合根实体定义（字段列表）
 }
 
 // NewContext 从项目配置创建模板上下文
 func NewContext(cfg *config.ProjectConfig) *Context {
-	return &Context{
-		ProjectName:  cfg.ProjectName,
-		ModulePath:   cfg.ModulePath,
-		UseRedis:     cfg.UseRedis,
-		Database:     cfg.Database,
-		DBDriver:     "gorm.io/driver/postgres",
-		DBDSNExample: "host=localhost user=postgres password=postgres dbname=" + cfg.ProjectName + " port=5432 sslmode=disable",
+	ctx := &Context{
+		ProjectName:    cfg.ProjectName,
+		ModulePath:     cfg.ModulePath,
+		UseRedis:       cfg.UseRedis,
+		Database:       cfg.Database,
+		DatabaseName:   config.DatabaseName(cfg.Database),
+		DBDriver:       "gorm.io/driver/" + cfg.Database,
+		Framework:      cfg.Framework,
+		FrameworkName:  config.FrameworkName(cfg.Framework),
+		ValidateTagKey: "vd",
+		QueryTagKey:    "query",
+	}
+	switch cfg.Framework {
+	case config.FrameworkGin:
+		ctx.ValidateTagKey = "binding"
+		ctx.QueryTagKey = "form"
+	case config.FrameworkChi:
+		ctx.ValidateTagKey = "validate"
+	}
+	switch cfg.Database {
+	case config.DatabaseMySQL:
+		ctx.DBType = "MySQL"
+		ctx.DBPort = 3306
+		ctx.DBDSNExample = "root:root@tcp(localhost:3306)/" + cfg.ProjectName + "?charset=utf8mb4&parseTime=True&loc=Local"
+	case config.DatabaseSQLite:
+		ctx.DBType = "SQLite"
+		ctx.DBDSNExample = cfg.ProjectName + ".db"
+	default:
+		ctx.DBType = "PostgreSQL"
+		ctx.DBPort = 5432
+		ctx.DBDSNExample = "host=localhost user=postgres password=postgres dbname=" + cfg.ProjectName + " port=5432 sslmode=disable"
 	}
+	return ctx
+}
+
+// WithAggregate 基于当前上下文派生聚合上下文，聚合名称取自实体名称
+func (c *Context) WithAggregate(entity *spec.Entity, errorCodeBase int) *Context {
+	aggCtx := *c
+	aggCtx.Aggregate = ToSnakeCase(entity.Name)
+	aggCtx.AggregatePascal = ToPascalCase(aggCtx.Aggregate)
+	aggCtx.AggregateCamel = ToCamelCase(aggCtx.Aggregate)
+	aggCtx.AggregateKebab = ToKebabCase(aggCtx.Aggregate)
+	aggCtx.AggregatePlural = Pluralize(aggCtx.Aggregate)
+	aggCtx.ErrorCodeBase = errorCodeBase
+
+	e := *entity
+	e.TypePrefix = aggCtx.AggregatePascal
+	aggCtx.Entity = &e
+	return &aggCtx
 }
 
-// Engine 模板引擎
-type Engine struct {
-	funcMap template.FuncMap
+// funcMap 模板函数
+var funcMap = template.FuncMap{
+	"toUpper":      strings.ToUpper,
+	"toLower":      strings.ToLower,
+	"toPascalCase": ToPascalCase,
+	"toCamelCase":  ToCamelCase,
+	"toSnakeCase":  ToSnakeCase,
+	"toKebabCase":  ToKebabCase,
+	"pluralize":    Pluralize,
+	"add":          func(a, b int) int { return a + b },
+	"mul":          func(a, b int) int { return a * b },
+	// columnType 返回字段类型在指定数据库中的列类型，例如 {{columnType "uuid" .Database}}
+	"columnType": func(t spec.FieldType, database string) string {
+		return spec.Field{Type: t}.ColumnType(database)
+	},
 }
 
+// Engine 模板引擎，模板以严格模式解析并在进程内缓存
+type Engine struct{}
+
 // NewEngine 创建模板引擎
 func NewEngine() *Engine {
-	return &Engine{
-		funcMap: template.FuncMap{
-			"toUpper":      strings.ToUpper,
-			"toLower":      strings.ToLower,
-			"toPascalCase": ToPascalCase,
-			"toCamelCase":  ToCamelCase,
-			"toSnakeCase":  ToSnakeCase,
-			"toKebabCase":  ToKebabCase,
-		},
-	}
+	return &Engine{}
 }
 
 // Render 渲染模板
 func (e *Engine) Render(tmplContent string, ctx *Context) (string, error) {
-	tmpl, err := template.New("template").Funcs(e.funcMap).Parse(tmplContent)
-	if err != nil {
-		return "", err
-	}
-
-	var buf bytes.Buffer
-	if err := tmpl.Execute(&buf, ctx); err != nil {
-		return "", err
-	}
-
-	return buf.String(), nil
+	return e.RenderNamed("template", tmplContent, ctx)
 }
@@ -0,0 +1,239 @@
+package template
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/scanner"
+	"go/token"
+	"path"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"golang.org/x/mod/modfile"
+)
+
+// FormatOutput 规范化生成的文件：Go 源文件经过 gofmt 和 goimports 风格的导入分组，
+// go.mod / go.work 经过 modfile 格式化，其他文件原样返回
+// localPrefix 为项目模块路径，项目内部的导入单独分为一组
+func FormatOutput(relativePath string, content []byte, localPrefix string) ([]byte, error) {
+	switch name := path.Base(relativePath); {
+	case strings.HasSuffix(name, ".go"):
+		return formatGo(relativePath, content, localPrefix)
+	case name == "go.mod":
+		f, err := modfile.Parse(relativePath, content, nil)
+		if err != nil {
+			return nil, err
+		}
+		f.Cleanup()
+		return modfile.Format(f.Syntax), nil
+	case name == "go.work":
+		f, err := modfile.ParseWork(relativePath, content, nil)
+		if err != nil {
+			return nil, err
+		}
+		f.Cleanup()
+		return modfile.Format(f.Syntax), nil
+	default:
+		return content, nil
+	}
+}
+
+// formatGo 格式化 Go 源文件，并按 标准库 / 第三方 / 项目内部 对导入分组
+func formatGo(filename string, src []byte, localPrefix string) ([]byte, error) {
+	formatted, err := format.Source(src)
+	if err != nil {
+		return nil, err
+	}
+
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, filename, formatted, parser.ImportsOnly|parser.ParseComments)
+	if err != nil {
+		return nil, err
+	}
+
+	lines := strings.SplitAfter(string(formatted), "\n")
+	// 从后往前替换，保证前面导入块的行号不变
+	for i := len(file.Decls) - 1; i >= 0; i-- {
+		decl, ok := file.Decls[i].(*ast.GenDecl)
+		if !ok || decl.Tok != token.IMPORT || !decl.Lparen.IsValid() {
+			continue
+		}
+		start := fset.Position(decl.Lparen).Line // import ( 所在行（从 1 开始），块内容从下一行开始
+		end := fset.Position(decl.Rparen).Line - 1
+		if end <= start {
+			continue // import () 写在同一行的空导入块
+		}
+		grouped := groupImports(lines[start:end], localPrefix)
+		lines = slices.Concat(lines[:start], grouped, lines[end:])
+	}
+
+	return format.Source([]byte(strings.Join(lines, "")))
+}
+
+// importPathPattern 导入行中的包路径
+var importPathPattern = regexp.MustCompile(`"([^"]+)"`)
+
+// importPath 返回导入行中的包路径
+func importPath(line string) string {
+	if m := importPathPattern.FindStringSubmatch(line); m != nil {
+		return m[1]
+	}
+	return ""
+}
+
+// importClass 导入分组：标准库、第三方、项目内部
+func importClass(line, localPrefix string) int {
+	switch p := importPath(line); {
+	case p == "":
+		return 0
+	case localPrefix != "" && (p == localPrefix || strings.HasPrefix(p, localPrefix+"/")):
+		return 2
+	case !strings.Contains(strings.Split(p, "/")[0], "."):
+		return 0
+	default:
+		return 1
+	}
+}
+
+// importEntry 导入块中的一个导入及其上方的注释
+type importEntry struct {
+	lines []string
+	path  string
+	class int
+}
+
+// groupImports 将导入块重新分为 标准库 / 第三方 / 项目内部 三组，组间以空行分隔
+// 模板中原有的空行分组不保留，否则写错分组的导入会留在错误的组中；
+// 导入上方的注释随导入一起移动，不属于任何导入的注释跟随它前面的导入
+func groupImports(lines []string, localPrefix string) []string {
+	var entries []importEntry
+	var pending []string // 尚未遇到导入行的注释
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case trimmed == "":
+			if len(pending) > 0 && len(entries) > 0 {
+				last := &entries[len(entries)-1]
+				last.lines = append(last.lines, pending...)
+				pending = nil
+			}
+		case strings.HasPrefix(trimmed, "//"):
+			pending = append(pending, line)
+		default:
+			entries = append(entries, importEntry{lines: append(pending, line), path: importPath(line), class: importClass(line, localPrefix)})
+			pending = nil
+		}
+	}
+	if len(pending) > 0 {
+		if len(entries) == 0 {
+			return pending
+		}
+		last := &entries[len(entries)-1]
+		last.lines = append(last.lines, pending...)
+	}
+
+	// 注释会打断 gofmt 对相邻导入的排序，组内按导入路径排好序
+	slices.SortStableFunc(entries, func(a, b importEntry) int {
+		if a.class != b.class {
+			return a.class - b.class
+		}
+		return strings.Compare(a.path, b.path)
+	})
+	var result []string
+	for i, e := range entries {
+		if i > 0 && e.class != entries[i-1].class {
+			result = append(result, "\n")
+		}
+		result = append(result, e.lines...)
+	}
+	return result
+}
+
+// SourceError 模板生成的文件无效（Go 代码无法解析、go.mod 格式错误等）
+type SourceError struct {
+	Template string // 模板路径
+	Path     string // 生成的文件路径
+	Line     int    // 出错行在模板中的行号，出错行包含模板语法而无法在模板中找到时为 0
+	Err      error  // 格式化时的错误
+
+	outLine int    // 出错行在生成结果中的行号，无法确定时为 0
+	code    string // 生成结果中出错的行
+	msg     string // 第一个语法错误的信息，不是 Go 语法错误时为空
+}
+
+// NewSourceError 根据格式化生成结果时的错误创建 SourceError
+// 出错行不包含模板语法时可以在模板中直接找到，此时记录模板中的行号，否则只能报告生成结果中的行号
+func NewSourceError(tmplName string, tmplContent, rendered []byte, outPath string, err error) *SourceError {
+	e := &SourceError{Template: tmplName, Path: outPath, Err: err}
+	var list scanner.ErrorList
+	if !errors.As(err, &list) || len(list) == 0 {
+		return e
+	}
+
+	first := list[0]
+	e.msg = first.Msg
+	renderedLines := strings.Split(string(rendered), "\n")
+	if first.Pos.Line < 1 || first.Pos.Line > len(renderedLines) {
+		return e
+	}
+	e.outLine = first.Pos.Line
+	e.code = strings.TrimSpace(renderedLines[first.Pos.Line-1])
+	e.Line = findTemplateLine(tmplContent, e.code)
+	return e
+}
+
+// Error 实现 error 接口，指明模板（或生成结果）中的出错位置
+func (e *SourceError) Error() string {
+	switch {
+	case e.msg == "":
+		return fmt.Sprintf("模板 %s 生成的 %s 无效: %v", e.Template, e.Path, e.Err)
+	case e.outLine == 0:
+		return fmt.Sprintf("模板 %s 生成的 %s 不是有效的 Go 代码: %s", e.Template, e.Path, e.msg)
+	}
+	location := e.Path + ":" + strconv.Itoa(e.outLine)
+	if e.Line > 0 {
+		location = e.Template + ":" + strconv.Itoa(e.Line)
+	}
+	return fmt.Sprintf("模板 %s 生成的 %s 不是有效的 Go 代码\n  %s: %s\n  > %s", e.Template, e.Path, location, e.msg, e.code)
+}
+
+// Unwrap 返回格式化时的错误
+func (e *SourceError) Unwrap() error {
+	return e.Err
+}
+
+// Detail 不含模板路径的单行说明，templates lint 以 模板:行号 报告时使用
+func (e *SourceError) Detail() string {
+	switch {
+	case e.msg == "":
+		return fmt.Sprintf("生成的 %s 无效: %v", e.Path, e.Err)
+	case e.outLine == 0:
+		return fmt.Sprintf("生成的 %s 不是有效的 Go 代码: %s", e.Path, e.msg)
+	case e.Line == 0:
+		return fmt.Sprintf("生成的 %s:%d 不是有效的 Go 代码: %s: %s", e.Path, e.outLine, e.msg, e.code)
+	}
+	return fmt.Sprintf("生成的 %s 不是有效的 Go 代码: %s: %s", e.Path, e.msg, e.code)
+}
+
+// findTemplateLine 在模板中查找与生成结果某一行相同且唯一的行，返回行号，找不到时返回 0
+func findTemplateLine(tmplContent []byte, line string) int {
+	if line == "" {
+		return 0
+	}
+	found := 0
+	for i, tmplLine := range bytes.Split(tmplContent, []byte("\n")) {
+		if strings.TrimSpace(string(tmplLine)) == line {
+			if found > 0 {
+				return 0
+			}
+			found = i + 1
+		}
+	}
+	return found
+}
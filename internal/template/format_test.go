@@ -0,0 +1,175 @@
+package template
+
+import (
+	"errors"
+	"go/scanner"
+	"strings"
+	"testing"
+)
+
+func TestFormatOutputGo(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want string
+	}{
+		{
+			name: "按标准库、第三方、项目内部分组",
+			src: `package demo
+
+import (
+	"example.com/demo/share/errors"
+	"github.com/google/uuid"
+	"context"
+	baseRepo "example.com/demo/share/repository"
+	"gorm.io/gorm"
+	"fmt"
+)
+`,
+			want: `package demo
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"example.com/demo/share/errors"
+	baseRepo "example.com/demo/share/repository"
+)
+`,
+		},
+		{
+			name: "重新分组时不保留原有的空行分组，注释随导入移动",
+			src: `package demo
+
+import (
+	"context"
+	"example.com/demo/share/errors"
+
+	// 项目内部
+	"example.com/demo/api/service"
+	"strings"
+
+	"github.com/google/uuid"
+	_ "embed"
+)
+`,
+			want: `package demo
+
+import (
+	"context"
+	_ "embed"
+	"strings"
+
+	"github.com/google/uuid"
+
+	// 项目内部
+	"example.com/demo/api/service"
+	"example.com/demo/share/errors"
+)
+`,
+		},
+		{
+			name: "gofmt 格式化",
+			src:  "package demo\nfunc  A( ) int {\nreturn 1}\n",
+			want: "package demo\n\nfunc A() int {\n\treturn 1\n}\n",
+		},
+		{
+			name: "单行导入和空导入块保持不变",
+			src:  "package demo\n\nimport \"fmt\"\n\nimport ()\n\nvar _ = fmt.Sprint\n",
+			want: "package demo\n\nimport \"fmt\"\n\nimport ()\n\nvar _ = fmt.Sprint\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := FormatOutput("demo/demo.go", []byte(tt.src), "example.com/demo")
+			if err != nil {
+				t.Fatalf("FormatOutput() error = %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("FormatOutput() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatOutputOtherFiles(t *testing.T) {
+	tests := []struct {
+		path    string
+		src     string
+		want    string
+		wantErr bool
+	}{
+		{
+			path: "share/go.mod",
+			src:  "module example.com/demo/share\ngo 1.24.11\nrequire github.com/google/uuid v1.6.0\n",
+			want: "module example.com/demo/share\n\ngo 1.24.11\n\nrequire github.com/google/uuid v1.6.0\n",
+		},
+		{path: "share/go.mod", src: "module\n", wantErr: true},
+		{path: "go.work", src: "go 1.24.11\nuse ./share\n", want: "go 1.24.11\n\nuse ./share\n"},
+		{path: "Makefile", src: "build:\n\tgo build  ./...\n", want: "build:\n\tgo build  ./...\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			got, err := FormatOutput(tt.path, []byte(tt.src), "example.com/demo")
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("FormatOutput() 应返回错误")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("FormatOutput() error = %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("FormatOutput() =\n%q\nwant\n%q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSourceError(t *testing.T) {
+	tests := []struct {
+		name     string
+		tmpl     string
+		rendered string
+		wantLine int    // 模板中的行号
+		wantMsg  string // Error() 中应包含的内容
+	}{
+		{
+			name:     "出错行可以在模板中找到",
+			tmpl:     "package {{.Pkg}}\n\nfunc A() {\n\treturn 1 +\n}\n",
+			rendered: "package demo\n\nfunc A() {\n\treturn 1 +\n}\n",
+			wantLine: 5,
+			wantMsg:  "demo.go.tmpl:5",
+		},
+		{
+			name:     "出错行包含模板语法时报告生成结果中的行号",
+			tmpl:     "package demo\n\nvar {{.Name}} = \n",
+			rendered: "package demo\n\nvar x = \n",
+			wantLine: 0,
+			wantMsg:  "demo.go:3",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := FormatOutput("demo.go", []byte(tt.rendered), "")
+			if err == nil {
+				t.Fatal("FormatOutput() 应返回错误")
+			}
+			sourceErr := NewSourceError("demo.go.tmpl", []byte(tt.tmpl), []byte(tt.rendered), "demo.go", err)
+			if sourceErr.Line != tt.wantLine {
+				t.Errorf("Line = %d, want %d", sourceErr.Line, tt.wantLine)
+			}
+			if !strings.Contains(sourceErr.Error(), tt.wantMsg) {
+				t.Errorf("Error() = %q, want containing %q", sourceErr.Error(), tt.wantMsg)
+			}
+			var list scanner.ErrorList
+			if !errors.As(sourceErr, &list) {
+				t.Error("SourceError 应包装格式化时的错误")
+			}
+		})
+	}
+}
@@ -49,6 +49,24 @@ func ToKebabCase(s string) string {
 	return strings.Join(words, "-")
 }
 
+// Pluralize 转换为英文复数形式（简单规则）
+// 例如: order -> orders, category -> categories, address -> addresses
+func Pluralize(s string) string {
+	if s == "" {
+		return s
+	}
+	lower := strings.ToLower(s)
+	switch {
+	case strings.HasSuffix(lower, "s"), strings.HasSuffix(lower, "x"), strings.HasSuffix(lower, "z"),
+		strings.HasSuffix(lower, "ch"), strings.HasSuffix(lower, "sh"):
+		return s + "es"
+	case strings.HasSuffix(lower, "y") && len(lower) > 1 && !strings.ContainsRune("aeiou", rune(lower[len(lower)-2])):
+		return s[:len(s)-1] + "ies"
+	default:
+		return s + "s"
+	}
+}
+
 // splitWords 按照下划线和中划线分割字符串
 func splitWords(s string) []string {
 	// 先替换中划线为下划线
@@ -0,0 +1,128 @@
+package template
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// Layers 按优先级叠加的模板树，前面的层覆盖后面的层中相同路径的文件，
+// 目录内容为各层的并集，因此上层既可以覆盖已有模板，也可以新增模板
+type Layers []fs.FS
+
+// Open 打开优先级最高的层中的文件
+func (l Layers) Open(name string) (fs.File, error) {
+	for _, layer := range l {
+		f, err := layer.Open(name)
+		if err == nil {
+			return f, nil
+		}
+		if !errors.Is(err, fs.ErrNotExist) {
+			return nil, err
+		}
+	}
+	return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+}
+
+// ReadDir 合并各层中同一目录的内容，同名条目以优先级高的层为准
+func (l Layers) ReadDir(name string) ([]fs.DirEntry, error) {
+	var entries []fs.DirEntry
+	seen := make(map[string]bool)
+	found := false
+	for _, layer := range l {
+		layerEntries, err := fs.ReadDir(layer, name)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		found = true
+		for _, entry := range layerEntries {
+			if !seen[entry.Name()] {
+				seen[entry.Name()] = true
+				entries = append(entries, entry)
+			}
+		}
+	}
+	if !found {
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: fs.ErrNotExist}
+	}
+
+	slices.SortFunc(entries, func(a, b fs.DirEntry) int {
+		return strings.Compare(a.Name(), b.Name())
+	})
+	return entries, nil
+}
+
+// Open 返回叠加了用户模板目录的模板树，dir 为空时只使用内置模板，不检查模板语法
+// 用户模板目录的结构与内置模板一致（例如 go/project/Dockerfile.tmpl），只需放入需要覆盖或新增的模板
+func Open(dir string) (fs.FS, error) {
+	if dir == "" {
+		return Builtin(), nil
+	}
+	info, err := os.Stat(dir)
+	if err != nil {
+		return nil, fmt.Errorf("读取模板目录失败: %w", err)
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("模板路径 '%s' 不是目录", dir)
+	}
+	return Layers{os.DirFS(dir), Builtin()}, nil
+}
+
+// Load 与 Open 相同，并解析全部模板，存在语法错误时返回错误
+func Load(dir string) (fs.FS, error) {
+	root, err := Open(dir)
+	if err != nil {
+		return nil, err
+	}
+	if err := Parse(root); err != nil {
+		return nil, fmt.Errorf("模板目录 '%s' 中存在语法错误:\n%w", dir, err)
+	}
+	return root, nil
+}
+
+// Export 将内置模板导出到 dir，force 为 false 时目录中已存在同名文件则不做任何修改并返回错误
+// 返回导出的文件路径（相对 dir 的 slash 路径）
+func Export(dir string, force bool) ([]string, error) {
+	var names []string
+	err := fs.WalkDir(Builtin(), ".", func(name string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		names = append(names, name)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if !force {
+		for _, name := range names {
+			target := filepath.Join(dir, filepath.FromSlash(name))
+			if _, err := os.Stat(target); err == nil {
+				return nil, fmt.Errorf("文件 '%s' 已存在（使用 --force 覆盖）", target)
+			}
+		}
+	}
+
+	for _, name := range names {
+		content, err := fs.ReadFile(Builtin(), name)
+		if err != nil {
+			return nil, err
+		}
+		target := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+			return nil, err
+		}
+		if err := os.WriteFile(target, content, 0644); err != nil {
+			return nil, err
+		}
+	}
+	return names, nil
+}
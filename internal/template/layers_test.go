@@ -0,0 +1,68 @@
+package template
+
+import (
+	"errors"
+	"io/fs"
+	"slices"
+	"testing"
+	"testing/fstest"
+)
+
+func TestLayers(t *testing.T) {
+	user := fstest.MapFS{
+		"go/project/Dockerfile.tmpl":    {Data: []byte("user dockerfile")},
+		"go/project/docs/extra.md.tmpl": {Data: []byte("user extra")},
+	}
+	team := fstest.MapFS{
+		"go/project/Dockerfile.tmpl": {Data: []byte("team dockerfile")},
+		"go/project/Makefile.tmpl":   {Data: []byte("team makefile")},
+	}
+	builtin := fstest.MapFS{
+		"go/project/Dockerfile.tmpl": {Data: []byte("builtin dockerfile")},
+		"go/project/Makefile.tmpl":   {Data: []byte("builtin makefile")},
+		"go/project/README.md.tmpl":  {Data: []byte("builtin readme")},
+	}
+	layers := Layers{user, team, builtin}
+
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"go/project/Dockerfile.tmpl", "user dockerfile"},
+		{"go/project/Makefile.tmpl", "team makefile"},
+		{"go/project/README.md.tmpl", "builtin readme"},
+		{"go/project/docs/extra.md.tmpl", "user extra"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := fs.ReadFile(layers, tt.name)
+			if err != nil {
+				t.Fatalf("ReadFile(%q) error = %v", tt.name, err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("ReadFile(%q) = %q, want %q", tt.name, got, tt.want)
+			}
+		})
+	}
+
+	// 目录内容为各层的并集，按名称排序
+	entries, err := fs.ReadDir(layers, "go/project")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	want := []string{"Dockerfile.tmpl", "Makefile.tmpl", "README.md.tmpl", "docs"}
+	if !slices.Equal(names, want) {
+		t.Errorf("ReadDir() = %v, want %v", names, want)
+	}
+
+	if _, err := fs.ReadFile(layers, "go/project/missing.tmpl"); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("ReadFile(missing) error = %v, want fs.ErrNotExist", err)
+	}
+	if _, err := fs.ReadDir(layers, "go/missing"); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("ReadDir(missing) error = %v, want fs.ErrNotExist", err)
+	}
+}
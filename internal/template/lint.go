@@ -0,0 +1,217 @@
+package template
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/tuza/scaffolding-code-generation/internal/config"
+	"github.com/tuza/scaffolding-code-generation/internal/spec"
+)
+
+// LintCase 检查模板使用的一组配置
+type LintCase struct {
+	Name      string     // 配置组合名称，例如 redis=on,database=postgres,framework=hertz
+	Project   *Context   // 项目模板上下文
+	Aggregate []*Context // 聚合模板上下文，每个实体定义一个
+}
+
+// LintIssue 模板检查发现的问题，同一问题在多个配置组合中出现时只报告一次
+type LintIssue struct {
+	Template string   // 模板在模板根目录中的路径
+	Line     int      // 行号，无法确定时为 0
+	Message  string   // 错误信息
+	Cases    []string // 出现该问题的配置组合
+}
+
+// String 以 file:line: message 的格式输出
+func (i LintIssue) String() string {
+	location := i.Template
+	if i.Line > 0 {
+		location += ":" + strconv.Itoa(i.Line)
+	}
+	return location + ": " + i.Message
+}
+
+// lintEntities 检查聚合模板使用的实体定义：默认实体、覆盖全部字段类型的 UUID 主键实体、无枚举的自增主键实体
+var lintEntities = [][]string{
+	nil,
+	{"id:uuid", "name:string(64):required:unique", "bio:text", "qty:int:unique", "seq:int64:index", "ratio:float",
+		"price:decimal:required", "active:bool", "ref:uuid:unique", "due:time", "status:enum(open,closed):required", "kind:enum(a,b)"},
+	{"id:int64", "code:string:unique"},
+}
+
+// LintMatrix 返回检查模板使用的配置组合：Redis 开/关 × 每种数据库 × 每种 HTTP 框架
+// 每个组合都会分别以 lintEntities 中的实体渲染聚合模板
+func LintMatrix() ([]LintCase, error) {
+	var cases []LintCase
+	for _, framework := range config.Frameworks {
+		for _, database := range config.Databases {
+			for _, useRedis := range []bool{true, false} {
+				cfg := config.NewProjectConfig()
+				cfg.ProjectName = "lint"
+				cfg.ModulePath = "example.com/lint"
+				cfg.OutputPath = "."
+				cfg.Framework = framework
+				cfg.Database = database
+				cfg.UseRedis = useRedis
+				ctx := NewContext(cfg)
+
+				lintCase := LintCase{
+					Name:    fmt.Sprintf("redis=%s,database=%s,framework=%s", onOff(useRedis), database, framework),
+					Project: ctx,
+				}
+				for i, fieldSpecs := range lintEntities {
+					entity := spec.DefaultEntity("lint_item")
+					if fieldSpecs != nil {
+						fields, err := spec.ParseFields(fieldSpecs)
+						if err != nil {
+							return nil, err
+						}
+						if entity, err = spec.NewEntity("lint_item", fields); err != nil {
+							return nil, err
+						}
+					}
+					lintCase.Aggregate = append(lintCase.Aggregate, ctx.WithAggregate(entity, 12000+1000*i))
+				}
+				cases = append(cases, lintCase)
+			}
+		}
+	}
+	return cases, nil
+}
+
+func onOff(b bool) string {
+	if b {
+		return "on"
+	}
+	return "off"
+}
+
+// Lint 解析并以每个配置组合渲染模板根目录中的全部模板，返回按模板和行号排序的问题
+func (e *Engine) Lint(root fs.FS, cases []LintCase) ([]LintIssue, error) {
+	issues := make(map[string]*LintIssue)
+	var order []string
+	syntax := make(map[string]bool)
+	report := func(found []LintIssue, caseName string) {
+		for _, issue := range found {
+			key := issue.String()
+			if caseName == "" {
+				syntax[key] = true
+			}
+			if _, ok := issues[key]; !ok {
+				issues[key] = &issue
+				order = append(order, key)
+			}
+			// 语法错误与配置无关，不记录配置组合
+			if caseName != "" && !syntax[key] && !slices.Contains(issues[key].Cases, caseName) {
+				issues[key].Cases = append(issues[key].Cases, caseName)
+			}
+		}
+	}
+
+	if err := Parse(root); err != nil {
+		report(parseTemplateErrors(err, ""), "")
+	}
+
+	for _, c := range cases {
+		// 只检查本组合所用框架的专属模板
+		for _, tree := range []string{TreeProject, FrameworkTree(c.Project.Framework, TreeProject)} {
+			found, err := e.lintTree(root, tree, c.Project, true)
+			if err != nil {
+				return nil, err
+			}
+			report(found, c.Name)
+		}
+
+		for _, ctx := range c.Aggregate {
+			name := c.Name + ",entity=" + strings.Join(ctx.Entity.FieldSpecs(), " ")
+			for _, tree := range []string{TreeAggregate, TreeSnippets, FrameworkTree(ctx.Framework, TreeAggregate), FrameworkTree(ctx.Framework, TreeSnippets)} {
+				// 片段模板生成的是插入 cmd/api/main.go 的代码片段，不是完整的 Go 文件，不做格式检查
+				isSnippets := tree == TreeSnippets || tree == FrameworkTree(ctx.Framework, TreeSnippets)
+				found, err := e.lintTree(root, tree, ctx, !isSnippets)
+				if err != nil {
+					return nil, err
+				}
+				report(found, name)
+			}
+		}
+	}
+
+	result := make([]LintIssue, 0, len(order))
+	for _, key := range order {
+		result = append(result, *issues[key])
+	}
+	slices.SortStableFunc(result, func(a, b LintIssue) int {
+		if c := strings.Compare(a.Template, b.Template); c != 0 {
+			return c
+		}
+		return a.Line - b.Line
+	})
+	return result, nil
+}
+
+// lintTree 渲染模板树中的全部模板（路径和内容），模板树不存在时跳过
+// format 为 true 时与生成时一样用 FormatOutput 规范化渲染结果，报告生成了无效 Go 代码或 go.mod 的模板
+func (e *Engine) lintTree(root fs.FS, tree string, ctx *Context, format bool) ([]LintIssue, error) {
+	var issues []LintIssue
+	err := fs.WalkDir(root, tree, func(name string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || !strings.HasSuffix(name, Suffix) {
+			return nil
+		}
+		outPath, pathErr := e.renderPath(name, ctx)
+		if pathErr != nil {
+			issues = append(issues, parseTemplateErrors(pathErr, name)...)
+		}
+		content, err := e.RenderFile(root, name, ctx)
+		if err != nil {
+			issues = append(issues, parseTemplateErrors(err, name)...)
+		}
+		if !format || pathErr != nil || err != nil || strings.TrimSpace(content) == "" {
+			return nil
+		}
+
+		outPath = strings.TrimPrefix(outPath, tree+"/")
+		if _, err := FormatOutput(outPath, []byte(content), ctx.ModulePath); err != nil {
+			tmplContent, _ := fs.ReadFile(root, name)
+			sourceErr := NewSourceError(name, tmplContent, []byte(content), outPath, err)
+			issues = append(issues, LintIssue{Template: name, Line: sourceErr.Line, Message: sourceErr.Detail()})
+		}
+		return nil
+	})
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	return issues, err
+}
+
+// templateErrorPattern text/template 错误信息格式：template: <name>:<line>[:<col>]: <message>
+var templateErrorPattern = regexp.MustCompile(`^template: (.+?):(\d+)(?::\d+)?: (.*)$`)
+
+// parseTemplateErrors 从（可能由 errors.Join 合并的）错误中提取模板路径和行号
+// 无法识别位置的错误归属于 name
+func parseTemplateErrors(err error, name string) []LintIssue {
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		var issues []LintIssue
+		for _, err := range joined.Unwrap() {
+			issues = append(issues, parseTemplateErrors(err, name)...)
+		}
+		return issues
+	}
+
+	msg := err.Error()
+	if m := templateErrorPattern.FindStringSubmatch(msg); m != nil {
+		line, _ := strconv.Atoi(m[2])
+		// 执行错误中包含冗余的 executing "<name>" at，只保留出错位置
+		message := strings.TrimPrefix(m[3], fmt.Sprintf("executing %q at ", m[1]))
+		return []LintIssue{{Template: m[1], Line: line, Message: message}}
+	}
+	return []LintIssue{{Template: name, Message: msg}}
+}
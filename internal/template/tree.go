@@ -0,0 +1,175 @@
+package template
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"strings"
+	"sync"
+	"text/template"
+)
+
+// builtinTemplates 内置模板树
+//
+// 目录结构与生成结果一致，路径段本身也是模板，例如
+// go/aggregate/{{.Aggregate}}/domain/entity/{{.Aggregate}}.go.tmpl
+//
+//go:embed all:templates
+var builtinTemplates embed.FS
+
+// 内置模板树
+const (
+	TreeProject   = "go/project"   // init 生成的项目骨架
+	TreeAggregate = "go/aggregate" // add aggregate / add entity 生成的聚合模块
+	TreeSnippets  = "go/snippets"  // 插入已有文件的代码片段
+	TreeHTTP      = "go/http"      // HTTP 框架专属模板，按框架划分，见 FrameworkTree
+)
+
+// FrameworkTree 返回模板树 tree 中 HTTP 框架专属的部分，例如 gin 的 TreeProject 为 go/http/gin/project
+// 框架专属模板树与 tree 一起遍历，两者不能输出同一个文件
+func FrameworkTree(framework, tree string) string {
+	return TreeHTTP + "/" + framework + "/" + path.Base(tree)
+}
+
+// Suffix 模板文件后缀，输出路径中会去掉
+const Suffix = ".tmpl"
+
+// 程序启动时解析全部内置模板，模板语法错误在启动时即可发现
+func init() {
+	if err := Parse(Builtin()); err != nil {
+		panic(fmt.Sprintf("内置模板无效: %v", err))
+	}
+}
+
+// Builtin 返回内置模板的根目录，其下为 TreeProject 等模板树
+func Builtin() fs.FS {
+	sub, err := fs.Sub(builtinTemplates, "templates")
+	if err != nil {
+		// 路径为编译期常量，出错说明内置模板目录结构有误
+		panic(err)
+	}
+	return sub
+}
+
+// parsedTemplates 已解析的模板缓存，键为模板名称和内容
+var parsedTemplates sync.Map
+
+// parse 严格模式解析模板：访问不存在的 map 键时报错，而不是输出 <no value>
+// name 为模板在模板根目录中的路径，用于错误信息中定位模板
+func parse(name, content string) (*template.Template, error) {
+	key := name + "\x00" + content
+	if tmpl, ok := parsedTemplates.Load(key); ok {
+		return tmpl.(*template.Template), nil
+	}
+
+	tmpl, err := template.New(name).Funcs(funcMap).Option("missingkey=error").Parse(content)
+	if err != nil {
+		return nil, err
+	}
+	parsedTemplates.Store(key, tmpl)
+	return tmpl, nil
+}
+
+// Parse 解析模板根目录中的全部模板（文件内容及路径中的模板片段），返回全部语法错误
+func Parse(root fs.FS) error {
+	var errs []error
+	err := fs.WalkDir(root, ".", func(name string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || !strings.HasSuffix(name, Suffix) {
+			return nil
+		}
+
+		if strings.Contains(name, "{{") {
+			if _, err := parse(name, strings.TrimSuffix(name, Suffix)); err != nil {
+				errs = append(errs, err)
+			}
+		}
+		content, err := fs.ReadFile(root, name)
+		if err != nil {
+			return err
+		}
+		if _, err := parse(name, string(content)); err != nil {
+			errs = append(errs, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	return errors.Join(errs...)
+}
+
+// TreeFile 模板树中的文件
+type TreeFile struct {
+	Template string // 模板在模板根目录中的路径（例如 go/project/Makefile.tmpl）
+	Path     string // 渲染后的输出路径（相对项目根目录，已去掉 .tmpl 后缀）
+}
+
+// Walk 按字典序遍历模板根目录 root 下的模板树 tree（例如 TreeProject），并渲染路径中的模板片段
+func (e *Engine) Walk(root fs.FS, tree string, ctx *Context) ([]TreeFile, error) {
+	var files []TreeFile
+	err := fs.WalkDir(root, tree, func(name string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || !strings.HasSuffix(name, Suffix) {
+			return nil
+		}
+
+		outPath, err := e.renderPath(name, ctx)
+		if err != nil {
+			return fmt.Errorf("渲染路径 %s 失败: %w", name, err)
+		}
+		relativePath, ok := strings.CutPrefix(outPath, tree+"/")
+		if !ok || relativePath == "" {
+			return fmt.Errorf("渲染路径 %s 失败: 渲染结果 '%s' 不在模板树 %s 中", name, outPath, tree)
+		}
+		files = append(files, TreeFile{Template: name, Path: relativePath})
+		return nil
+	})
+	return files, err
+}
+
+// renderPath 渲染模板文件路径并去掉 .tmpl 后缀，不包含模板语法的路径原样返回
+func (e *Engine) renderPath(name string, ctx *Context) (string, error) {
+	p := strings.TrimSuffix(name, Suffix)
+	if !strings.Contains(p, "{{") {
+		return p, nil
+	}
+	rendered, err := e.RenderNamed(name, p, ctx)
+	if err != nil {
+		return "", err
+	}
+	if rendered = path.Clean(rendered); rendered == "." || strings.HasPrefix(rendered, "../") {
+		return "", fmt.Errorf("渲染结果 '%s' 不是有效的相对路径", rendered)
+	}
+	return rendered, nil
+}
+
+// RenderFile 渲染模板根目录中的文件，渲染结果为空白时表示该文件不需要生成
+func (e *Engine) RenderFile(root fs.FS, name string, ctx *Context) (string, error) {
+	content, err := fs.ReadFile(root, name)
+	if err != nil {
+		return "", err
+	}
+	return e.RenderNamed(name, string(content), ctx)
+}
+
+// RenderNamed 渲染模板，name 用于错误信息中定位模板
+func (e *Engine) RenderNamed(name, tmplContent string, ctx *Context) (string, error) {
+	tmpl, err := parse(name, tmplContent)
+	if err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, ctx); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
@@ -0,0 +1,225 @@
+package upgrade
+
+import (
+	"bytes"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/tuza/scaffolding-code-generation/internal/config"
+	"github.com/tuza/scaffolding-code-generation/internal/diff"
+	"github.com/tuza/scaffolding-code-generation/internal/generator"
+	"github.com/tuza/scaffolding-code-generation/internal/manifest"
+	"github.com/tuza/scaffolding-code-generation/internal/merge"
+	"github.com/tuza/scaffolding-code-generation/internal/output"
+	"github.com/tuza/scaffolding-code-generation/internal/version"
+)
+
+// Action 文件的升级结果
+type Action string
+
+const (
+	ActionUnchanged Action = "unchanged" // 新模板未改变该文件
+	ActionUpdated   Action = "updated"   // 用户未修改，直接替换为新版本
+	ActionKept      Action = "kept"      // 只有用户修改，保留当前版本
+	ActionMerged    Action = "merged"    // 双方修改，已自动合并
+	ActionConflict  Action = "conflict"  // 双方修改冲突，已写入冲突标记
+	ActionRejected  Action = "rejected"  // 无法合并，新模板的修改写入 .rej 文件
+	ActionAdded     Action = "added"     // 新模板新增的文件
+	ActionSkipped   Action = "skipped"   // 用户已删除的文件，不再生成
+	ActionObsolete  Action = "obsolete"  // 新模板不再生成的文件，保留不动
+)
+
+// Applied 新模板的修改是否已干净地应用到项目文件（冲突或写入 .rej 时为 false）
+func (a Action) Applied() bool {
+	return a != ActionConflict && a != ActionRejected
+}
+
+// RejectSuffix 无法合并时写入的差异文件后缀
+const RejectSuffix = ".rej"
+
+// Options 升级选项
+type Options struct {
+	DryRun bool // 只报告结果，不修改任何文件
+	Reject bool // 冲突时保留当前文件，将新模板的修改写入 .rej 文件，而不是写入冲突标记
+
+	Templates fs.FS // 模板根目录，nil 表示使用内置模板
+}
+
+// FileResult 单个文件的升级结果
+type FileResult struct {
+	Path      string
+	Action    Action
+	Conflicts int // 冲突数量（仅 ActionConflict）
+}
+
+// Report 升级报告
+type Report struct {
+	FromVersion string
+	ToVersion   string
+	Files       []FileResult
+}
+
+// Count 统计指定结果的文件数量
+func (r *Report) Count(action Action) int {
+	n := 0
+	for _, f := range r.Files {
+		if f.Action == action {
+			n++
+		}
+	}
+	return n
+}
+
+// Upgrader 项目升级器：用当前版本的模板重新生成项目，并与用户的修改进行三方合并
+type Upgrader struct {
+	projectDir string
+	config     *config.ProjectConfig
+	manifest   *manifest.Manifest
+	opts       Options
+}
+
+// New 创建升级器，projectDir 为项目根目录，m 为项目当前的清单
+func New(projectDir string, cfg *config.ProjectConfig, m *manifest.Manifest, opts Options) *Upgrader {
+	return &Upgrader{
+		projectDir: projectDir,
+		config:     cfg,
+		manifest:   m,
+		opts:       opts,
+	}
+}
+
+// Run 执行升级
+func (u *Upgrader) Run() (*Report, error) {
+	rendered := output.NewMemFS()
+	newManifest, err := generator.RenderProject(u.config, u.manifest, rendered, u.opts.Templates)
+	if err != nil {
+		return nil, fmt.Errorf("重新生成项目失败: %w", err)
+	}
+
+	report := &Report{
+		FromVersion: u.manifest.ToolVersion,
+		ToVersion:   version.Version,
+	}
+	labels := merge.Labels{
+		Current: "current",
+		Base:    "archi-gen " + u.manifest.ToolVersion,
+		New:     "archi-gen " + version.Version,
+	}
+
+	for _, path := range sortedKeys(newManifest.Files) {
+		updated, err := rendered.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		result, err := u.upgradeFile(path, updated, labels)
+		if err != nil {
+			return nil, fmt.Errorf("升级 %s 失败: %w", path, err)
+		}
+		if !result.Action.Applied() {
+			// 新模板的修改未能干净应用，保留旧快照作为下一次升级的共同祖先，否则这些修改会被视为已应用而丢失
+			newManifest.RetainFile(path, u.manifest.Files[path])
+		}
+		report.Files = append(report.Files, result)
+	}
+
+	for _, path := range sortedKeys(u.manifest.Files) {
+		if _, ok := newManifest.Files[path]; !ok {
+			report.Files = append(report.Files, FileResult{Path: path, Action: ActionObsolete})
+		}
+	}
+
+	if u.opts.DryRun {
+		return report, nil
+	}
+
+	// 保留项目的创建时间，并将已应用的新生成内容作为下一次升级的共同祖先
+	newManifest.CreatedAt = u.manifest.CreatedAt
+	if err := newManifest.Save(u.projectDir); err != nil {
+		return nil, fmt.Errorf("更新 %s 失败: %w", manifest.FileName, err)
+	}
+	return report, nil
+}
+
+// upgradeFile 对单个文件进行三方比较并写入结果
+func (u *Upgrader) upgradeFile(path string, updated []byte, labels merge.Labels) (FileResult, error) {
+	result := FileResult{Path: path}
+
+	current, err := os.ReadFile(filepath.Join(u.projectDir, filepath.FromSlash(path)))
+	if os.IsNotExist(err) {
+		if _, generated := u.manifest.Files[path]; generated {
+			result.Action = ActionSkipped
+			return result, nil
+		}
+		result.Action = ActionAdded
+		return result, u.write(path, updated)
+	}
+	if err != nil {
+		return result, err
+	}
+
+	if bytes.Equal(current, updated) {
+		result.Action = ActionUnchanged
+		return result, nil
+	}
+
+	base, hasBase := manifest.LoadBase(u.projectDir, path)
+	if !hasBase {
+		// 没有快照时只能依据校验和判断用户是否修改过
+		if u.manifest.Files[path] == manifest.Checksum(current) {
+			result.Action = ActionUpdated
+			return result, u.write(path, updated)
+		}
+		result.Action = ActionRejected
+		rej := diff.Unified(path, path, string(current), string(updated), diff.DefaultContext)
+		return result, u.write(path+RejectSuffix, []byte(rej))
+	}
+
+	switch {
+	case bytes.Equal(current, base):
+		result.Action = ActionUpdated
+		return result, u.write(path, updated)
+	case bytes.Equal(updated, base):
+		result.Action = ActionKept
+		return result, nil
+	}
+
+	merged := merge.ThreeWay(string(base), string(current), string(updated), labels)
+	if merged.Conflicts == 0 {
+		result.Action = ActionMerged
+		return result, u.write(path, []byte(merged.Content))
+	}
+
+	result.Conflicts = merged.Conflicts
+	if u.opts.Reject {
+		result.Action = ActionRejected
+		rej := diff.Unified(path, path, string(base), string(updated), diff.DefaultContext)
+		return result, u.write(path+RejectSuffix, []byte(rej))
+	}
+	result.Action = ActionConflict
+	return result, u.write(path, []byte(merged.Content))
+}
+
+// write 写入项目文件（dry-run 时跳过）
+func (u *Upgrader) write(path string, content []byte) error {
+	if u.opts.DryRun {
+		return nil
+	}
+	fullPath := filepath.Join(u.projectDir, filepath.FromSlash(path))
+	if err := os.MkdirAll(filepath.Dir(fullPath), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(fullPath, content, 0644)
+}
+
+// sortedKeys 返回排序后的文件路径
+func sortedKeys(files map[string]string) []string {
+	keys := make([]string, 0, len(files))
+	for k := range files {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	return keys
+}
@@ -0,0 +1,264 @@
+package upgrade
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/tuza/scaffolding-code-generation/internal/config"
+	"github.com/tuza/scaffolding-code-generation/internal/generator"
+	"github.com/tuza/scaffolding-code-generation/internal/manifest"
+	"github.com/tuza/scaffolding-code-generation/internal/version"
+)
+
+// upgradedFile 测试中用来模拟各种升级场景的文件
+const upgradedFile = "Makefile"
+
+func TestUpgraderRun(t *testing.T) {
+	// 模板的修改都在第一行，用户的修改都在最后一行，两者可以自动合并
+	older := func(s string) string { return replaceLine(s, 0, "# archi-gen old") }
+	mine := func(s string) string { return replaceLine(s, -1, "# my change") }
+
+	tests := []struct {
+		name         string
+		reject       bool
+		recorded     bool                         // 清单中是否记录了该文件
+		base         func(rendered string) string // 上一次生成的内容（快照）
+		current      func(rendered string) string // 项目中的当前内容，nil 表示用户已删除
+		wantAction   Action
+		wantContent  func(rendered string) string // 升级后项目中的内容，nil 表示文件不存在
+		wantRetained bool                         // 清单是否保留上一次生成的校验和与快照
+	}{
+		{
+			name:        "模板未改变",
+			recorded:    true,
+			base:        same,
+			current:     same,
+			wantAction:  ActionUnchanged,
+			wantContent: same,
+		},
+		{
+			name:        "用户未修改，替换为新版本",
+			recorded:    true,
+			base:        older,
+			current:     older,
+			wantAction:  ActionUpdated,
+			wantContent: same,
+		},
+		{
+			name:        "只有用户修改，保留当前版本",
+			recorded:    true,
+			base:        same,
+			current:     mine,
+			wantAction:  ActionKept,
+			wantContent: mine,
+		},
+		{
+			name:        "双方修改不同区域，自动合并",
+			recorded:    true,
+			base:        older,
+			current:     func(s string) string { return mine(older(s)) },
+			wantAction:  ActionMerged,
+			wantContent: mine,
+		},
+		{
+			name:       "双方修改同一区域，写入冲突标记",
+			recorded:   true,
+			base:       older,
+			current:    func(s string) string { return replaceLine(s, 0, "# my header") },
+			wantAction: ActionConflict,
+			wantContent: func(s string) string {
+				label := "archi-gen " + version.Version
+				return replaceLine(s, 0, "<<<<<<< current\n# my header\n||||||| "+label+"\n# archi-gen old\n=======\n"+
+					firstLine(s)+"\n>>>>>>> "+label)
+			},
+			wantRetained: true,
+		},
+		{
+			name:         "双方修改同一区域，--reject 时写入 .rej 文件",
+			reject:       true,
+			recorded:     true,
+			base:         older,
+			current:      func(s string) string { return replaceLine(s, 0, "# my header") },
+			wantAction:   ActionRejected,
+			wantContent:  func(s string) string { return replaceLine(s, 0, "# my header") },
+			wantRetained: true,
+		},
+		{
+			name:       "用户已删除的文件不再生成",
+			recorded:   true,
+			base:       older,
+			wantAction: ActionSkipped,
+		},
+		{
+			name:        "新模板新增的文件",
+			wantAction:  ActionAdded,
+			wantContent: same,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			projectDir, m := generateProject(t)
+			path := filepath.Join(projectDir, upgradedFile)
+			rendered := readFile(t, path)
+
+			var base string
+			if tt.recorded {
+				base = tt.base(rendered)
+				writeFile(t, filepath.Join(projectDir, manifest.BaseDir, upgradedFile), base)
+				m.Files[upgradedFile] = manifest.Checksum([]byte(base))
+			} else {
+				delete(m.Files, upgradedFile)
+			}
+			if err := m.Save(projectDir); err != nil {
+				t.Fatal(err)
+			}
+			if tt.current != nil {
+				writeFile(t, path, tt.current(rendered))
+			} else if err := os.Remove(path); err != nil {
+				t.Fatal(err)
+			}
+
+			report := runUpgrade(t, projectDir, m, Options{Reject: tt.reject})
+
+			if got := fileAction(report, upgradedFile); got != tt.wantAction {
+				t.Errorf("Action = %q, want %q", got, tt.wantAction)
+			}
+			if tt.wantContent != nil {
+				if got, want := readFile(t, path), tt.wantContent(rendered); got != want {
+					t.Errorf("升级后的内容 =\n%s\nwant\n%s", got, want)
+				}
+			} else if _, err := os.Stat(path); !os.IsNotExist(err) {
+				t.Errorf("%s 不应被重新生成", upgradedFile)
+			}
+			if _, err := os.Stat(path + RejectSuffix); (err == nil) != (tt.wantAction == ActionRejected) {
+				t.Errorf("%s%s 存在 = %v, want %v", upgradedFile, RejectSuffix, err == nil, tt.wantAction == ActionRejected)
+			}
+
+			// 未能干净应用新模板时保留旧快照，下一次升级仍以它为共同祖先
+			want := rendered
+			if tt.wantRetained {
+				want = base
+			}
+			upgraded, err := manifest.Load(projectDir)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := upgraded.Files[upgradedFile]; got != manifest.Checksum([]byte(want)) {
+				t.Errorf("清单中的校验和 = %s, want %s", got, manifest.Checksum([]byte(want)))
+			}
+			if got, _ := manifest.LoadBase(projectDir, upgradedFile); string(got) != want {
+				t.Errorf("快照 =\n%s\nwant\n%s", got, want)
+			}
+		})
+	}
+}
+
+func TestUpgraderRunDryRun(t *testing.T) {
+	projectDir, m := generateProject(t)
+	path := filepath.Join(projectDir, upgradedFile)
+	rendered := readFile(t, path)
+	base := replaceLine(rendered, 0, "# archi-gen old")
+	writeFile(t, filepath.Join(projectDir, manifest.BaseDir, upgradedFile), base)
+	writeFile(t, path, base)
+	m.Files[upgradedFile] = manifest.Checksum([]byte(base))
+	if err := m.Save(projectDir); err != nil {
+		t.Fatal(err)
+	}
+	before := readFile(t, filepath.Join(projectDir, manifest.FileName))
+
+	report := runUpgrade(t, projectDir, m, Options{DryRun: true})
+
+	if got := fileAction(report, upgradedFile); got != ActionUpdated {
+		t.Errorf("Action = %q, want %q", got, ActionUpdated)
+	}
+	if got := readFile(t, path); got != base {
+		t.Errorf("dry-run 不应修改项目文件")
+	}
+	if got := readFile(t, filepath.Join(projectDir, manifest.FileName)); got != before {
+		t.Errorf("dry-run 不应修改 %s", manifest.FileName)
+	}
+}
+
+// generateProject 在临时目录中生成项目，返回项目目录和清单
+func generateProject(t *testing.T) (string, *manifest.Manifest) {
+	t.Helper()
+	cfg := config.NewProjectConfig()
+	cfg.ProjectName = "demo"
+	cfg.ModulePath = "example.com/demo"
+	cfg.OutputPath = t.TempDir()
+	gen := generator.NewGoGenerator(cfg)
+	gen.SetOutput(io.Discard)
+	if err := gen.Generate(); err != nil {
+		t.Fatal(err)
+	}
+
+	projectDir := filepath.Join(cfg.OutputPath, cfg.ProjectName)
+	m, err := manifest.Load(projectDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return projectDir, m
+}
+
+// runUpgrade 以清单中的配置执行升级
+func runUpgrade(t *testing.T, projectDir string, m *manifest.Manifest, opts Options) *Report {
+	t.Helper()
+	cfg, err := m.ProjectConfig(projectDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	report, err := New(projectDir, cfg, m, opts).Run()
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	return report
+}
+
+// fileAction 返回报告中指定文件的升级结果
+func fileAction(report *Report, path string) Action {
+	for _, f := range report.Files {
+		if f.Path == path {
+			return f.Action
+		}
+	}
+	return ""
+}
+
+func same(s string) string { return s }
+
+// replaceLine 替换第 i 行（负数表示从末尾倒数），s 以换行结尾
+func replaceLine(s string, i int, line string) string {
+	lines := strings.Split(strings.TrimSuffix(s, "\n"), "\n")
+	if i < 0 {
+		i += len(lines)
+	}
+	lines[i] = line
+	return strings.Join(lines, "\n") + "\n"
+}
+
+func firstLine(s string) string {
+	line, _, _ := strings.Cut(s, "\n")
+	return line
+}
+
+func readFile(t *testing.T, path string) string {
+	t.Helper()
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(content)
+}
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
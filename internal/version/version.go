@@ -0,0 +1,6 @@
+package version
+
+// Version archi-gen 版本号，发布构建时可通过以下参数覆盖:
+//
+//	go build -ldflags "-X github.com/tuza/scaffolding-code-generation/internal/version.Version=1.2.0"
+var Version = "1.0.0"